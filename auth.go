@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,21 +18,50 @@ type TokenResponse struct {
 	ExpiresIn   int    `json:"expires_in"`
 }
 
+// cachedToken is an access token together with the time it stops being usable
+type cachedToken struct {
+	accessToken string
+	expiry      time.Time
+}
+
+// tokenFetchTimeout bounds a shared token fetch, which does not follow the
+// context of any single caller
+const tokenFetchTimeout = 30 * time.Second
+
+// tokenFetch is an in-flight token request shared by concurrent callers
+type tokenFetch struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
 // AuthService handles authentication with the MTN MoMo API
 type AuthService struct {
-	client      *Client
-	config      *Config
-	tokenMutex  sync.Mutex
-	accessToken string
-	tokenExpiry time.Time
+	client *Client
+	config *Config
+
+	// Tokens are cached per product and credential set
+	tokenMutex sync.Mutex
+	tokens     map[string]cachedToken
+	inflight   map[string]*tokenFetch
+
+	// API user and key provisioned on demand in sandbox mode
+	credMutex sync.Mutex
+	apiUser   string
+	apiKey    string
 }
 
-// NewAuthService creates a new authentication service
+// NewAuthService creates a new authentication service. Tokens rejected by
+// the API with 401 on requests sent through client are dropped from the cache.
 func NewAuthService(client *Client, config *Config) *AuthService {
-	return &AuthService{
-		client: client,
-		config: config,
+	s := &AuthService{
+		client:   client,
+		config:   config,
+		tokens:   make(map[string]cachedToken),
+		inflight: make(map[string]*tokenFetch),
 	}
+	client.onUnauthorized = s.InvalidateToken
+	return s
 }
 
 // CreateAPIUser creates a new API user for sandbox environment
@@ -92,49 +122,132 @@ func (s *AuthService) CreateAPIKey(ctx context.Context, apiUserID string) (strin
 	return result.APIKey, nil
 }
 
-// GetAccessToken fetches a new access token or returns a cached one if still valid
+// GetAccessToken fetches a new access token or returns a cached one if still valid.
+// Tokens are cached separately for each product and set of credentials, and
+// concurrent callers asking for the same token share a single request.
 func (s *AuthService) GetAccessToken(ctx context.Context, product string) (string, error) {
+	tokenPath, subscriptionKey, err := s.productTokenConfig(product)
+	if err != nil {
+		return "", err
+	}
+
+	apiUser, apiKey, err := s.credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	key := tokenCacheKey(product, apiUser, subscriptionKey)
+
 	s.tokenMutex.Lock()
-	defer s.tokenMutex.Unlock()
 
 	// Check if we have a valid cached token
-	if s.accessToken != "" && time.Now().Before(s.tokenExpiry) {
-		return s.accessToken, nil
+	if cached, ok := s.tokens[key]; ok && time.Now().Before(cached.expiry) {
+		s.tokenMutex.Unlock()
+		return cached.accessToken, nil
 	}
 
-	// Determine which API user and key to use
-	apiUser := s.config.APIUser
-	apiKey := s.config.APIKey
+	// Join a fetch that is already in progress, or start one. The fetch runs
+	// detached from ctx so that one caller giving up does not fail the others.
+	fetch, ok := s.inflight[key]
+	if !ok {
+		fetch = &tokenFetch{done: make(chan struct{})}
+		s.inflight[key] = fetch
+		go s.runTokenFetch(context.WithoutCancel(ctx), key, fetch, tokenPath, apiUser, apiKey, subscriptionKey)
+	}
+	s.tokenMutex.Unlock()
 
-	// For sandbox, create them if not already set
-	if s.config.Environment == Sandbox && (apiUser == "" || apiKey == "") {
-		var err error
-		apiUser, err = s.CreateAPIUser(ctx)
-		if err != nil {
-			return "", err
-		}
+	select {
+	case <-fetch.done:
+		return fetch.token, fetch.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// runTokenFetch performs a shared token fetch and caches the result
+func (s *AuthService) runTokenFetch(ctx context.Context, key string, fetch *tokenFetch, tokenPath, apiUser, apiKey, subscriptionKey string) {
+	ctx, cancel := context.WithTimeout(ctx, tokenFetchTimeout)
+	defer cancel()
 
-		apiKey, err = s.CreateAPIKey(ctx, apiUser)
-		if err != nil {
-			return "", err
+	tokenResp, err := s.fetchToken(ctx, tokenPath, apiUser, apiKey, subscriptionKey)
+
+	s.tokenMutex.Lock()
+	delete(s.inflight, key)
+	if err == nil {
+		// Cache the token, expiring 1 minute early to be safe
+		s.tokens[key] = cachedToken{
+			accessToken: tokenResp.AccessToken,
+			expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn-60) * time.Second),
 		}
+		fetch.token = tokenResp.AccessToken
 	}
+	fetch.err = err
+	s.tokenMutex.Unlock()
+	close(fetch.done)
+}
 
-	// Determine the right path based on product
-	tokenPath := ""
-	subscriptionKey := ""
+// InvalidateToken drops any cached tokens for the given product
+func (s *AuthService) InvalidateToken(product string) {
+	s.tokenMutex.Lock()
+	defer s.tokenMutex.Unlock()
+
+	prefix := product + "|"
+	for key := range s.tokens {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.tokens, key)
+		}
+	}
+}
 
+// productTokenConfig returns the token path and subscription key for a product
+func (s *AuthService) productTokenConfig(product string) (string, string, error) {
 	switch product {
 	case "collection":
-		tokenPath = "/collection/token/"
-		subscriptionKey = s.config.SubscriptionKey
+		return "/collection/token/", s.config.SubscriptionKey, nil
 	case "disbursement":
-		tokenPath = "/disbursement/token/"
-		subscriptionKey = s.config.DisbursementKey
+		return "/disbursement/token/", s.config.DisbursementKey, nil
+	case "remittance":
+		return "/remittance/token/", s.config.RemittanceKey, nil
 	default:
-		return "", fmt.Errorf("unknown product: %s", product)
+		return "", "", fmt.Errorf("unknown product: %s", product)
+	}
+}
+
+// credentials returns the API user and key to authenticate with. In sandbox
+// mode they are created on first use if not configured, and then reused.
+func (s *AuthService) credentials(ctx context.Context) (string, string, error) {
+	if s.config.APIUser != "" && s.config.APIKey != "" {
+		return s.config.APIUser, s.config.APIKey, nil
+	}
+	if s.config.Environment != Sandbox {
+		return s.config.APIUser, s.config.APIKey, nil
+	}
+
+	s.credMutex.Lock()
+	defer s.credMutex.Unlock()
+
+	if s.apiUser != "" && s.apiKey != "" {
+		return s.apiUser, s.apiKey, nil
+	}
+
+	apiUser, err := s.CreateAPIUser(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	apiKey, err := s.CreateAPIKey(ctx, apiUser)
+	if err != nil {
+		return "", "", err
 	}
 
+	s.apiUser = apiUser
+	s.apiKey = apiKey
+
+	return apiUser, apiKey, nil
+}
+
+// fetchToken requests a new access token from the API
+func (s *AuthService) fetchToken(ctx context.Context, tokenPath, apiUser, apiKey, subscriptionKey string) (*TokenResponse, error) {
 	var tokenResp TokenResponse
 	req := Request{
 		Method: http.MethodPost,
@@ -143,16 +256,18 @@ func (s *AuthService) GetAccessToken(ctx context.Context, product string) (strin
 			"Authorization":             CreateBasicAuthHeader(apiUser, apiKey),
 			"Ocp-Apim-Subscription-Key": subscriptionKey,
 		},
+		Idempotent: true,
 	}
 
 	err := s.client.DoRequest(ctx, req, &tokenResp)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch access token: %w", err)
+		return nil, fmt.Errorf("failed to fetch access token: %w", err)
 	}
 
-	// Cache the token
-	s.accessToken = tokenResp.AccessToken
-	s.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn-60) * time.Second) // Expire 1 minute early to be safe
+	return &tokenResp, nil
+}
 
-	return s.accessToken, nil
+// tokenCacheKey identifies a token by product and the credentials used to obtain it
+func tokenCacheKey(product, apiUser, subscriptionKey string) string {
+	return product + "|" + apiUser + "|" + subscriptionKey
 }
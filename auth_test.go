@@ -0,0 +1,117 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestConfig(t *testing.T, baseURL string, opts ...ConfigOption) *Config {
+	t.Helper()
+
+	config, err := NewConfig(Sandbox, append([]ConfigOption{
+		WithSubscriptionKey("key"),
+		WithAPIUser("user"),
+		WithAPIKey("secret"),
+		WithBaseURL(baseURL),
+		WithRetryPolicy(NoRetryPolicy()),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	return config
+}
+
+func TestGetAccessTokenSurvivesCancelledLeader(t *testing.T) {
+	var hits atomic.Int32
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		received <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"access_token","expires_in":3600}`)
+	}))
+	defer srv.Close()
+
+	config := newTestConfig(t, srv.URL)
+	auth := NewAuthService(NewClient(config), config)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := auth.GetAccessToken(leaderCtx, "collection")
+		leaderErr <- err
+	}()
+	<-received
+
+	type result struct {
+		token string
+		err   error
+	}
+	follower := make(chan result, 1)
+	go func() {
+		token, err := auth.GetAccessToken(context.Background(), "collection")
+		follower <- result{token, err}
+	}()
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader error = %v, want context.Canceled", err)
+	}
+
+	// Give the follower time to join the in-flight fetch before it completes
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	got := <-follower
+	if got.err != nil || got.token != "token-1" {
+		t.Fatalf("follower = %q, %v; want token-1, nil", got.token, got.err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("token endpoint hit %d times, want 1", n)
+	}
+}
+
+func TestUnauthorizedInvalidatesToken(t *testing.T) {
+	var tokens atomic.Int32
+	var balances atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /collection/token/", func(w http.ResponseWriter, r *http.Request) {
+		n := tokens.Add(1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"access_token","expires_in":3600}`, n)
+	})
+	mux.HandleFunc("GET /collection/v1_0/account/balance", func(w http.ResponseWriter, r *http.Request) {
+		if balances.Add(1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code":"UNAUTHORIZED","message":"expired token"}`)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token-2" {
+			t.Errorf("Authorization = %q, want a fresh token", got)
+		}
+		fmt.Fprint(w, `{"availableBalance":"10.00","currency":"EUR"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := NewMoMoClient(newTestConfig(t, srv.URL))
+	ctx := context.Background()
+
+	if _, err := client.Collection.GetBalance(ctx); err == nil {
+		t.Fatal("first GetBalance succeeded, want 401")
+	}
+	if _, err := client.Collection.GetBalance(ctx); err != nil {
+		t.Fatalf("second GetBalance: %v", err)
+	}
+	if n := tokens.Load(); n != 2 {
+		t.Errorf("token endpoint hit %d times, want 2", n)
+	}
+}
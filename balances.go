@@ -0,0 +1,41 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// balanceFunc fetches the balance of an account in one currency
+type balanceFunc func(ctx context.Context, currency string) (*Balance, error)
+
+// getBalances fetches balances in several currencies concurrently. Balances
+// that could be fetched are returned along with the errors of the others.
+func getBalances(ctx context.Context, currencies []string, fetch balanceFunc) (map[string]*Balance, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		balances = make(map[string]*Balance, len(currencies))
+		errs     []error
+	)
+
+	for _, currency := range currencies {
+		wg.Add(1)
+		go func(currency string) {
+			defer wg.Done()
+
+			balance, err := fetch(ctx, currency)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			balances[currency] = balance
+		}(currency)
+	}
+	wg.Wait()
+
+	return balances, errors.Join(errs...)
+}
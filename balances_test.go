@@ -0,0 +1,71 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBalanceUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantRaw       string
+		wantAvailable Money
+		wantMoneyErr  bool
+	}{
+		{"decimal", `{"availableBalance":"1500.50","currency":"EUR"}`, "1500.50", Money{150050, "EUR"}, false},
+		{"whole", `{"availableBalance":"1500","currency":"UGX"}`, "1500", Money{1500, "UGX"}, false},
+		{"unparseable amount", `{"availableBalance":"1,500.00","currency":"EUR"}`, "1,500.00", Money{}, true},
+		{"missing currency", `{"availableBalance":"1500"}`, "1500", Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var balance Balance
+			if err := json.Unmarshal([]byte(tt.body), &balance); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if balance.AvailableBalance != tt.wantRaw {
+				t.Errorf("AvailableBalance = %q, want %q", balance.AvailableBalance, tt.wantRaw)
+			}
+			if balance.Available != tt.wantAvailable {
+				t.Errorf("Available = %+v, want %+v", balance.Available, tt.wantAvailable)
+			}
+
+			money, err := balance.Money()
+			if (err != nil) != tt.wantMoneyErr {
+				t.Fatalf("Money error = %v, wantErr %v", err, tt.wantMoneyErr)
+			}
+			if err == nil && money != tt.wantAvailable {
+				t.Errorf("Money = %+v, want %+v", money, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestBalanceUnmarshalJSONMalformed(t *testing.T) {
+	var balance Balance
+	if err := json.Unmarshal([]byte(`{"availableBalance":`), &balance); err == nil {
+		t.Error("Unmarshal of malformed JSON succeeded")
+	}
+}
+
+func TestGetBalances(t *testing.T) {
+	fetch := func(ctx context.Context, currency string) (*Balance, error) {
+		if currency == "XAF" {
+			return nil, fmt.Errorf("no %s account: %w", currency, ErrNotFound)
+		}
+		return &Balance{AvailableBalance: "10", Currency: currency, Available: NewMoney(1000, currency)}, nil
+	}
+
+	balances, err := getBalances(context.Background(), []string{"EUR", "USD", "XAF"}, fetch)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+	if len(balances) != 2 || balances["EUR"] == nil || balances["USD"] == nil {
+		t.Errorf("balances = %v, want EUR and USD", balances)
+	}
+}
@@ -0,0 +1,464 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallbackKind identifies the operation a callback notifies about
+type CallbackKind string
+
+const (
+	// CallbackRequestToPay is a collection request-to-pay notification
+	CallbackRequestToPay CallbackKind = "requesttopay"
+	// CallbackRequestToWithdraw is a collection request-to-withdraw notification
+	CallbackRequestToWithdraw CallbackKind = "requesttowithdraw"
+	// CallbackPreApproval is a collection pre-approval notification
+	CallbackPreApproval CallbackKind = "preapproval"
+	// CallbackInvoice is a collection invoice notification
+	CallbackInvoice CallbackKind = "invoice"
+	// CallbackPayment is a collection bill payment notification
+	CallbackPayment CallbackKind = "payment"
+	// CallbackTransfer is a disbursement transfer notification
+	CallbackTransfer CallbackKind = "transfer"
+	// CallbackDeposit is a disbursement deposit notification
+	CallbackDeposit CallbackKind = "deposit"
+	// CallbackRefund is a disbursement refund notification
+	CallbackRefund CallbackKind = "refund"
+	// CallbackRemittanceTransfer is a remittance transfer notification
+	CallbackRemittanceTransfer CallbackKind = "remittancetransfer"
+	// CallbackCashTransfer is a remittance cash transfer notification
+	CallbackCashTransfer CallbackKind = "cashtransfer"
+)
+
+// maxCallbackBodySize limits how much of a callback body is read
+const maxCallbackBodySize = 1 << 20
+
+// CallbackEvent is a transaction notification received from MTN
+type CallbackEvent struct {
+	TransactionStatusResponse
+	Kind        CallbackKind // Operation the callback is about
+	ReferenceID string       // X-Reference-Id of the original request
+	Confirmed   bool         // Status was confirmed with a status lookup
+}
+
+// CallbackFunc handles a callback event. Returning an error makes the handler
+// respond with a 500 so that MTN delivers the callback again.
+type CallbackFunc func(ctx context.Context, event *CallbackEvent) error
+
+// CallbackHandler is an http.Handler receiving MTN MoMo callbacks.
+//
+// MTN callback bodies do not carry the reference ID, so it is taken from the
+// callback URL: the last two path segments are read as {kind}/{referenceId},
+// e.g. /momo/callback/requesttopay/6f3c.... The "kind" and "referenceId"
+// query parameters and the X-Reference-Id header are used as fallbacks.
+type CallbackHandler struct {
+	handlers map[CallbackKind]CallbackFunc
+	client   *MoMoClient // Used to confirm events when set
+	dedupTTL time.Duration
+
+	mu        sync.Mutex
+	inflight  map[string]bool      // Events being handled
+	done      map[string]time.Time // Delivered events and when they are forgotten
+	nextSweep time.Time
+}
+
+// CallbackOption configures a CallbackHandler
+type CallbackOption func(*CallbackHandler)
+
+// NewCallbackHandler creates a new callback handler
+func NewCallbackHandler(opts ...CallbackOption) *CallbackHandler {
+	h := &CallbackHandler{
+		handlers: make(map[CallbackKind]CallbackFunc),
+		dedupTTL: 24 * time.Hour,
+		inflight: make(map[string]bool),
+		done:     make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// OnRequestToPay sets the handler for request-to-pay callbacks
+func OnRequestToPay(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackRequestToPay] = fn
+	}
+}
+
+// OnRequestToWithdraw sets the handler for request-to-withdraw callbacks
+func OnRequestToWithdraw(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackRequestToWithdraw] = fn
+	}
+}
+
+// OnPreApproval sets the handler for pre-approval callbacks
+func OnPreApproval(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackPreApproval] = fn
+	}
+}
+
+// OnInvoice sets the handler for invoice callbacks
+func OnInvoice(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackInvoice] = fn
+	}
+}
+
+// OnPayment sets the handler for bill payment callbacks
+func OnPayment(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackPayment] = fn
+	}
+}
+
+// OnTransfer sets the handler for transfer callbacks
+func OnTransfer(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackTransfer] = fn
+	}
+}
+
+// OnDeposit sets the handler for deposit callbacks
+func OnDeposit(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackDeposit] = fn
+	}
+}
+
+// OnRefund sets the handler for refund callbacks
+func OnRefund(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackRefund] = fn
+	}
+}
+
+// OnRemittanceTransfer sets the handler for remittance transfer callbacks
+func OnRemittanceTransfer(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackRemittanceTransfer] = fn
+	}
+}
+
+// OnCashTransfer sets the handler for remittance cash transfer callbacks
+func OnCashTransfer(fn CallbackFunc) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.handlers[CallbackCashTransfer] = fn
+	}
+}
+
+// WithCallbackConfirmation makes the handler look up the status of every
+// event through the API before dispatching it, instead of trusting the body
+func WithCallbackConfirmation(client *MoMoClient) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.client = client
+	}
+}
+
+// WithCallbackDedupTTL sets how long delivered events are remembered to drop
+// repeated deliveries (24 hours by default, zero disables deduplication)
+func WithCallbackDedupTTL(ttl time.Duration) CallbackOption {
+	return func(h *CallbackHandler) {
+		h.dedupTTL = ttl
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind, referenceID := callbackTarget(r)
+	handler, ok := h.handlers[kind]
+	if !ok {
+		http.Error(w, "unknown callback", http.StatusNotFound)
+		return
+	}
+	if referenceID == "" {
+		http.Error(w, "missing reference ID", http.StatusBadRequest)
+		return
+	}
+
+	event := &CallbackEvent{
+		Kind:        kind,
+		ReferenceID: referenceID,
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBodySize))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &event.TransactionStatusResponse); err != nil {
+		http.Error(w, "invalid callback body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if h.client != nil {
+		if err := h.confirm(ctx, event); err != nil {
+			http.Error(w, "error confirming callback", http.StatusBadGateway)
+			return
+		}
+	}
+
+	key := string(kind) + "|" + referenceID + "|" + string(event.Status)
+	switch h.claim(key) {
+	case eventDelivered:
+		w.WriteHeader(http.StatusOK)
+		return
+	case eventInFlight:
+		// Not acknowledged, so MTN delivers it again if the first attempt fails
+		http.Error(w, "callback is already being handled", http.StatusConflict)
+		return
+	}
+
+	delivered := false
+	defer func() { h.finish(key, delivered) }()
+
+	if err := handler(ctx, event); err != nil {
+		http.Error(w, "error handling callback", http.StatusInternalServerError)
+		return
+	}
+
+	delivered = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// confirm replaces the event status with the one returned by the API
+func (h *CallbackHandler) confirm(ctx context.Context, event *CallbackEvent) error {
+	var status *TransactionStatusResponse
+	var err error
+
+	switch event.Kind {
+	case CallbackRequestToPay:
+		status, err = h.client.Collection.GetTransactionStatus(ctx, event.ReferenceID)
+	case CallbackRequestToWithdraw:
+		status, err = h.client.Collection.GetWithdrawStatus(ctx, event.ReferenceID)
+	case CallbackPreApproval:
+		var preApproval *PreApprovalStatusResponse
+		preApproval, err = h.client.Collection.GetPreApprovalStatus(ctx, event.ReferenceID)
+		if err == nil {
+			status = &TransactionStatusResponse{
+				Currency: preApproval.PayerCurrency,
+				Payer:    preApproval.Payer,
+				Status:   preApproval.Status,
+				Reason:   preApproval.Reason,
+			}
+		}
+	case CallbackInvoice:
+		var invoice *InvoiceStatusResponse
+		invoice, err = h.client.Collection.GetInvoiceStatus(ctx, event.ReferenceID)
+		if err == nil {
+			status = &TransactionStatusResponse{
+				Amount:     invoice.Amount,
+				Currency:   invoice.Currency,
+				ExternalID: invoice.ExternalID,
+				Payer:      invoice.IntendedPayer,
+				Status:     TransactionStatus(invoice.Status),
+			}
+			if invoice.ErrorReason != nil {
+				status.Reason = invoice.ErrorReason.Code
+			}
+		}
+	case CallbackPayment:
+		var payment *PaymentStatusResponse
+		payment, err = h.client.Collection.GetPaymentStatus(ctx, event.ReferenceID)
+		if err == nil {
+			status = &TransactionStatusResponse{
+				Status:                 payment.Status,
+				FinancialTransactionID: payment.FinancialTransactionID,
+			}
+			if payment.Reason != nil {
+				status.Reason = payment.Reason.Code
+			}
+		}
+	case CallbackTransfer:
+		status, err = h.client.Disbursement.GetTransferStatus(ctx, event.ReferenceID)
+	case CallbackDeposit:
+		status, err = h.client.Disbursement.GetDepositStatus(ctx, event.ReferenceID)
+	case CallbackRefund:
+		status, err = h.client.Disbursement.GetRefundStatus(ctx, event.ReferenceID)
+	case CallbackRemittanceTransfer:
+		status, err = h.client.Remittance.GetTransferStatus(ctx, event.ReferenceID)
+	case CallbackCashTransfer:
+		var cashStatus *CashTransferStatusResponse
+		cashStatus, err = h.client.Remittance.GetCashTransferStatus(ctx, event.ReferenceID)
+		if err == nil {
+			status = &cashStatus.TransactionStatusResponse
+		}
+	default:
+		// No status lookup for this kind, dispatch it unconfirmed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	event.TransactionStatusResponse = *status
+	event.Confirmed = true
+	return nil
+}
+
+// callbackSweepInterval is how often expired events are dropped
+const callbackSweepInterval = time.Minute
+
+// eventState is the deduplication state of a callback event
+type eventState int
+
+const (
+	eventNew       eventState = iota // Not seen yet, now claimed by the caller
+	eventInFlight                    // Being handled by another delivery
+	eventDelivered                   // Already handled successfully
+)
+
+// claim marks an event as in flight unless it is already being handled or
+// was delivered
+func (h *CallbackHandler) claim(key string) eventState {
+	if h.dedupTTL <= 0 {
+		return eventNew
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if now.After(h.nextSweep) {
+		for k, expiry := range h.done {
+			if now.After(expiry) {
+				delete(h.done, k)
+			}
+		}
+		h.nextSweep = now.Add(callbackSweepInterval)
+	}
+
+	if expiry, ok := h.done[key]; ok && now.Before(expiry) {
+		return eventDelivered
+	}
+	if h.inflight[key] {
+		return eventInFlight
+	}
+	h.inflight[key] = true
+	return eventNew
+}
+
+// finish ends the handling of a claimed event. Only delivered events are
+// remembered; failed ones are handled again when MTN retries them.
+func (h *CallbackHandler) finish(key string, delivered bool) {
+	if h.dedupTTL <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.inflight, key)
+	if delivered {
+		h.done[key] = time.Now().Add(h.dedupTTL)
+	}
+}
+
+// callbackTarget extracts the callback kind and reference ID from a request
+func callbackTarget(r *http.Request) (CallbackKind, string) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) >= 2 {
+		kind := CallbackKind(strings.ToLower(segments[len(segments)-2]))
+		if isCallbackKind(kind) {
+			return kind, segments[len(segments)-1]
+		}
+	}
+
+	query := r.URL.Query()
+	kind := CallbackKind(strings.ToLower(query.Get("kind")))
+	if len(segments) >= 1 && kind == "" {
+		kind = CallbackKind(strings.ToLower(segments[len(segments)-1]))
+	}
+
+	referenceID := query.Get("referenceId")
+	if referenceID == "" {
+		referenceID = r.Header.Get("X-Reference-Id")
+	}
+
+	return kind, referenceID
+}
+
+// isCallbackKind reports whether kind is a known callback kind
+func isCallbackKind(kind CallbackKind) bool {
+	switch kind {
+	case CallbackRequestToPay, CallbackRequestToWithdraw, CallbackPreApproval, CallbackInvoice, CallbackPayment,
+		CallbackTransfer, CallbackDeposit, CallbackRefund,
+		CallbackRemittanceTransfer, CallbackCashTransfer:
+		return true
+	default:
+		return false
+	}
+}
+
+// callbackURL returns the X-Callback-Url for a request: the given URL if set,
+// otherwise one built from the configured callback host and path template.
+// An empty string means no callback is requested.
+func (c *Config) callbackURL(kind CallbackKind, referenceID, override string) (string, error) {
+	callbackURL := override
+	if callbackURL == "" {
+		if c.CallbackPath == "" || c.CallbackHost == "" {
+			return "", nil
+		}
+		path := strings.NewReplacer(
+			"{kind}", string(kind),
+			"{referenceId}", url.PathEscape(referenceID),
+		).Replace(c.CallbackPath)
+		callbackURL = strings.TrimRight(callbackBase(c.CallbackHost), "/") + "/" + strings.TrimLeft(path, "/")
+	}
+
+	if err := c.validateCallbackURL(callbackURL); err != nil {
+		return "", err
+	}
+	return callbackURL, nil
+}
+
+// validateCallbackURL checks a callback URL. In production it must use https
+// and point at the provider callback host registered for the API user.
+func (c *Config) validateCallbackURL(callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: %q is not an absolute http(s) URL", ErrInvalidCallbackURL, callbackURL)
+	}
+
+	if c.Environment != Production {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: %q must use https in production", ErrInvalidCallbackURL, callbackURL)
+	}
+	if c.CallbackHost != "" {
+		registered, err := url.Parse(callbackBase(c.CallbackHost))
+		if err == nil && !strings.EqualFold(registered.Hostname(), u.Hostname()) {
+			return fmt.Errorf("%w: host %q does not match callback host %q", ErrInvalidCallbackURL, u.Hostname(), registered.Hostname())
+		}
+	}
+
+	return nil
+}
+
+// callbackBase turns a callback host, which may be configured with or
+// without a scheme, into a base URL
+func callbackBase(host string) string {
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "https://" + host
+}
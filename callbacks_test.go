@@ -0,0 +1,283 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func deliver(h http.Handler, path, body string) int {
+	req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestCallbackTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		header   string
+		wantKind CallbackKind
+		wantRef  string
+	}{
+		{"path", "/momo/callback/requesttopay/ref-1", "", CallbackRequestToPay, "ref-1"},
+		{"path is case-insensitive", "/cb/Transfer/ref-2", "", CallbackTransfer, "ref-2"},
+		{"query", "/cb?kind=deposit&referenceId=ref-3", "", CallbackDeposit, "ref-3"},
+		{"kind in path, header reference", "/cb/refund", "ref-4", CallbackRefund, "ref-4"},
+		{"unknown", "/cb/other", "", "other", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Reference-Id", tt.header)
+			}
+			kind, ref := callbackTarget(req)
+			if kind != tt.wantKind || ref != tt.wantRef {
+				t.Errorf("callbackTarget = %q, %q; want %q, %q", kind, ref, tt.wantKind, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestCallbackHandlerRejectsBadRequests(t *testing.T) {
+	h := NewCallbackHandler(OnRequestToPay(func(ctx context.Context, event *CallbackEvent) error { return nil }))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "/cb/requesttopay/ref", `{}`, http.StatusMethodNotAllowed},
+		{"no handler for kind", http.MethodPut, "/cb/transfer/ref", `{}`, http.StatusNotFound},
+		{"missing reference", http.MethodPut, "/cb/requesttopay", `{}`, http.StatusBadRequest},
+		{"invalid body", http.MethodPut, "/cb/requesttopay/ref", `{`, http.StatusBadRequest},
+		{"ok", http.MethodPost, "/cb/requesttopay/ref", `{"status":"SUCCESSFUL"}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallbackDedupConcurrentDuplicates(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fail := atomic.Bool{}
+	fail.Store(true)
+
+	h := NewCallbackHandler(OnRequestToPay(func(ctx context.Context, event *CallbackEvent) error {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		if fail.Load() {
+			return errors.New("database down")
+		}
+		return nil
+	}))
+
+	const path = "/cb/requesttopay/ref-1"
+	const body = `{"status":"SUCCESSFUL"}`
+
+	first := make(chan int, 1)
+	go func() { first <- deliver(h, path, body) }()
+	<-started
+
+	// A duplicate arriving while the first is in flight is not acknowledged
+	if code := deliver(h, path, body); code != http.StatusConflict {
+		t.Errorf("in-flight duplicate status = %d, want %d", code, http.StatusConflict)
+	}
+
+	// The first delivery fails, so the event must not be remembered
+	close(release)
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Fatalf("first delivery status = %d, want 500", code)
+	}
+
+	fail.Store(false)
+	if code := deliver(h, path, body); code != http.StatusOK {
+		t.Errorf("redelivery status = %d, want 200", code)
+	}
+	if code := deliver(h, path, body); code != http.StatusOK {
+		t.Errorf("duplicate status = %d, want 200", code)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("handler called %d times, want 2", n)
+	}
+
+	// A new status for the same transaction is a different event
+	if code := deliver(h, path, `{"status":"FAILED"}`); code != http.StatusOK {
+		t.Errorf("new status delivery = %d, want 200", code)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("handler called %d times, want 3", n)
+	}
+}
+
+func TestCallbackDedupExpiry(t *testing.T) {
+	var calls atomic.Int32
+	h := NewCallbackHandler(
+		OnTransfer(func(ctx context.Context, event *CallbackEvent) error {
+			calls.Add(1)
+			return nil
+		}),
+		WithCallbackDedupTTL(10*time.Millisecond),
+	)
+
+	deliver(h, "/cb/transfer/ref", `{"status":"SUCCESSFUL"}`)
+	deliver(h, "/cb/transfer/ref", `{"status":"SUCCESSFUL"}`)
+	time.Sleep(20 * time.Millisecond)
+	deliver(h, "/cb/transfer/ref", `{"status":"SUCCESSFUL"}`)
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("handler called %d times, want 2", n)
+	}
+}
+
+func TestCallbackURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      EnvironmentType
+		host     string
+		path     string
+		override string
+		want     string
+		wantErr  bool
+	}{
+		{"no callback", Sandbox, "", "", "", "", false},
+		{"template", Sandbox, "example.com", "/momo/{kind}/{referenceId}", "", "https://example.com/momo/requesttopay/ref", false},
+		{"host with scheme", Sandbox, "http://localhost:8080", "cb/{kind}/{referenceId}", "", "http://localhost:8080/cb/requesttopay/ref", false},
+		{"override", Sandbox, "", "", "http://other.test/cb", "http://other.test/cb", false},
+		{"relative override", Sandbox, "", "", "/cb", "", true},
+		{"production needs https", Production, "example.com", "", "http://example.com/cb", "", true},
+		{"production host mismatch", Production, "example.com", "", "https://evil.test/cb", "", true},
+		{"production ok", Production, "example.com", "", "https://example.com/cb", "https://example.com/cb", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Environment: tt.env, CallbackHost: tt.host, CallbackPath: tt.path}
+			got, err := config.callbackURL(CallbackRequestToPay, "ref", tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidCallbackURL) {
+				t.Errorf("errors.Is(%v, ErrInvalidCallbackURL) = false", err)
+			}
+			if got != tt.want {
+				t.Errorf("callbackURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// confirmationServer serves request-to-pay status lookups, failing them while
+// fail is set
+func confirmationServer(t *testing.T, fail *atomic.Bool, lookups *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /collection/token/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+	})
+	mux.HandleFunc("GET /collection/v1_0/requesttopay/{referenceId}", func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"amount":"100","currency":"EUR","financialTransactionId":"ft-1","status":"SUCCESSFUL"}`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCallbackConfirmation(t *testing.T) {
+	var fail atomic.Bool
+	var lookups atomic.Int32
+	srv := confirmationServer(t, &fail, &lookups)
+
+	var got *CallbackEvent
+	h := NewCallbackHandler(
+		WithCallbackConfirmation(NewMoMoClient(newTestConfig(t, srv.URL))),
+		OnRequestToPay(func(ctx context.Context, event *CallbackEvent) error {
+			got = event
+			return nil
+		}),
+	)
+
+	// The body claims the payment failed, the API says otherwise
+	if code := deliver(h, "/cb/requesttopay/ref-1", `{"status":"FAILED","reason":"INTERNAL_PROCESSING_ERROR"}`); code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", code, http.StatusOK)
+	}
+	if got == nil {
+		t.Fatal("handler was not called")
+	}
+	if !got.Confirmed {
+		t.Error("Confirmed = false, want true")
+	}
+	if got.Status != Successful || got.Reason != "" || got.FinancialTransactionID != "ft-1" {
+		t.Errorf("event = %+v, want the status returned by the API", got.TransactionStatusResponse)
+	}
+	if got.ReferenceID != "ref-1" || got.Kind != CallbackRequestToPay {
+		t.Errorf("event target = %s/%s, want requesttopay/ref-1", got.Kind, got.ReferenceID)
+	}
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("lookups = %d, want 1", n)
+	}
+}
+
+func TestCallbackConfirmationFailure(t *testing.T) {
+	var fail atomic.Bool
+	var lookups atomic.Int32
+	srv := confirmationServer(t, &fail, &lookups)
+
+	var calls atomic.Int32
+	h := NewCallbackHandler(
+		WithCallbackConfirmation(NewMoMoClient(newTestConfig(t, srv.URL))),
+		OnRequestToPay(func(ctx context.Context, event *CallbackEvent) error {
+			calls.Add(1)
+			return nil
+		}),
+	)
+
+	fail.Store(true)
+	if code := deliver(h, "/cb/requesttopay/ref-1", `{"status":"SUCCESSFUL"}`); code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want %d", code, http.StatusBadGateway)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("handler called %d times for an unconfirmed event", n)
+	}
+
+	// MTN retries the callback once the lookup works again
+	fail.Store(false)
+	if code := deliver(h, "/cb/requesttopay/ref-1", `{"status":"SUCCESSFUL"}`); code != http.StatusOK {
+		t.Fatalf("retry status = %d, want %d", code, http.StatusOK)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want the retry to be processed once", n)
+	}
+	if n := lookups.Load(); n != 2 {
+		t.Errorf("lookups = %d, want 2", n)
+	}
+}
@@ -0,0 +1,376 @@
+package gomomo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a cassette records or replays traffic
+type CassetteMode int
+
+const (
+	// Record sends requests to the API and saves the redacted traffic
+	Record CassetteMode = iota
+	// Replay serves responses from a saved cassette without network access
+	Replay
+)
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is an API request with its credentials redacted and its
+// MSISDNs pseudonymized
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// RecordedResponse is an API response with its credentials redacted and its
+// MSISDNs pseudonymized
+type RecordedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// CassetteMismatch describes a replayed request that matched no interaction
+type CassetteMismatch struct {
+	Method     string
+	Path       string
+	Body       string
+	Candidates []RecordedRequest // Recorded requests with the same method and path
+	Exhausted  bool              // Matching interactions exist but were all used
+}
+
+// String formats the mismatch for test output
+func (m CassetteMismatch) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", m.Method, m.Path)
+	if m.Body != "" {
+		fmt.Fprintf(&b, " body=%s", m.Body)
+	}
+	switch {
+	case m.Exhausted:
+		b.WriteString(": all matching interactions already replayed")
+	case len(m.Candidates) == 0:
+		b.WriteString(": no interaction recorded for this method and path")
+	default:
+		b.WriteString(": body differs from recorded requests")
+		for _, candidate := range m.Candidates {
+			fmt.Fprintf(&b, "\n  recorded body=%s", candidate.Body)
+		}
+	}
+	return b.String()
+}
+
+// Cassette records API traffic to a file or replays it, for deterministic
+// offline integration tests. Credentials (subscription keys, authorization
+// headers, API keys and access tokens) are redacted before anything is
+// written, and every MSISDN in paths and bodies is replaced with a pseudonym
+// derived from the number with a keyed hash. The same number always gets the
+// same pseudonym, and live requests are pseudonymized the same way during
+// replay, so requests for different account holders never match each other.
+// Install it with WithInterceptors(cassette.Interceptor()).
+type Cassette struct {
+	path string
+	mode CassetteMode
+	key  []byte // Key of the MSISDN pseudonyms
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	mismatches   []CassetteMismatch
+}
+
+// defaultCassetteKey is the pseudonym key used unless WithCassetteKey is given
+const defaultCassetteKey = "gomomo-cassette"
+
+// CassetteOption configures a Cassette
+type CassetteOption func(*Cassette)
+
+// WithCassetteKey sets the key MSISDN pseudonyms are derived with. Phone
+// numbers are short enough to be recovered from an unkeyed hash by trying
+// them all, so cassettes recorded against real subscribers should use a
+// secret key, which must then also be given when replaying them. An empty
+// key keeps the default one.
+func WithCassetteKey(key string) CassetteOption {
+	return func(c *Cassette) {
+		if key != "" {
+			c.key = []byte(key)
+		}
+	}
+}
+
+// cassetteFile is the on-disk format of a cassette
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewCassette opens a cassette file. In Replay mode the file must exist; in
+// Record mode it is written by Save.
+func NewCassette(path string, mode CassetteMode, opts ...CassetteOption) (*Cassette, error) {
+	cassette := &Cassette{path: path, mode: mode, key: []byte(defaultCassetteKey)}
+	for _, opt := range opts {
+		opt(cassette)
+	}
+	if mode == Record {
+		return cassette, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette: %w", err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error decoding cassette %s: %w", path, err)
+	}
+	cassette.interactions = file.Interactions
+	cassette.used = make([]bool, len(file.Interactions))
+	return cassette, nil
+}
+
+// Interceptor returns the interceptor that records or replays calls
+func (c *Cassette) Interceptor() Interceptor {
+	if c.mode == Record {
+		return c.record
+	}
+	return c.replay
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing in Replay mode.
+func (c *Cassette) Save() error {
+	if c.mode != Record {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("error creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Mismatches returns the replayed requests that matched no interaction
+func (c *Cassette) Mismatches() []CassetteMismatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]CassetteMismatch(nil), c.mismatches...)
+}
+
+// Err reports every mismatch seen during replay, or nil if there was none
+func (c *Cassette) Err() error {
+	var errs []error
+	for _, mismatch := range c.Mismatches() {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrCassetteMismatch, mismatch))
+	}
+	return errors.Join(errs...)
+}
+
+// record sends the call and saves the exchange with credentials redacted and
+// MSISDNs pseudonymized
+func (c *Cassette) record(call *Call, next Invoker) (*http.Response, error) {
+	resp, err := next(call)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: c.recordRequest(call.HTTPRequest),
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       c.scrubBody(body),
+		},
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the call
+func (c *Cassette) replay(call *Call, next Invoker) (*http.Response, error) {
+	// Pseudonymized like the recorded requests, so MSISDNs match exactly
+	request := c.recordRequest(call.HTTPRequest)
+	key := matchKey(request)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	mismatch := CassetteMismatch{Method: request.Method, Path: request.Path, Body: request.Body}
+	for i, interaction := range c.interactions {
+		recorded := interaction.Request
+		if recorded.Method != request.Method || normalizeUUIDs(recorded.Path) != normalizeUUIDs(request.Path) {
+			continue
+		}
+		if matchKey(recorded) != key {
+			mismatch.Candidates = append(mismatch.Candidates, recorded)
+			continue
+		}
+		if c.used[i] {
+			mismatch.Exhausted = true
+			continue
+		}
+
+		c.used[i] = true
+		return interaction.Response.httpResponse(call.HTTPRequest), nil
+	}
+
+	if mismatch.Exhausted {
+		mismatch.Candidates = nil
+	}
+	c.mismatches = append(c.mismatches, mismatch)
+	return nil, fmt.Errorf("%w: %s", ErrCassetteMismatch, mismatch)
+}
+
+// httpResponse rebuilds an HTTP response from a recorded one
+func (r RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	header := make(http.Header, len(r.Headers))
+	for key, value := range r.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		StatusCode:    r.StatusCode,
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// recordRequest captures an outgoing request with its credentials redacted
+// and MSISDNs pseudonymized
+func (c *Cassette) recordRequest(req *http.Request) RecordedRequest {
+	return RecordedRequest{
+		Method:  req.Method,
+		Path:    c.scrubPath(req.URL.EscapedPath()),
+		Query:   req.URL.RawQuery,
+		Headers: redactHeaders(req.Header),
+		Body:    c.scrubBody(readRequestBody(req)),
+	}
+}
+
+// scrubPath pseudonymizes the MSISDN of an account holder path such as
+// /collection/v1_0/accountholder/msisdn/{msisdn}/active
+func (c *Cassette) scrubPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if PartyIDType(strings.ToUpper(segments[i-1])) == MSISDN {
+			segments[i] = c.pseudonym(segments[i])
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// scrubBody redacts the secret fields of a JSON body and pseudonymizes its
+// MSISDNs, keeping everything else as is. Bodies that are not JSON are kept
+// verbatim.
+func (c *Cassette) scrubBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	data, err := json.Marshal(c.scrubValue(value))
+	if err != nil {
+		return redacted
+	}
+	return string(data)
+}
+
+// scrubValue walks a decoded JSON value redacting secret fields and
+// pseudonymizing MSISDNs
+func (c *Cassette) scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch {
+			case secretFields[key]:
+				v[key] = redacted
+			case isMSISDNField(key, v):
+				if s, ok := field.(string); ok {
+					v[key] = c.pseudonym(s)
+				}
+			default:
+				v[key] = c.scrubValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = c.scrubValue(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// pseudonym derives a stable stand-in for an MSISDN from a keyed hash. It has
+// as many digits as the number, so it still looks like one to the code under
+// test.
+func (c *Cassette) pseudonym(msisdn string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(msisdn))
+	sum := mac.Sum(nil)
+
+	digits := make([]byte, len(msisdn))
+	for i := range digits {
+		digits[i] = '0' + sum[i%len(sum)]%10
+	}
+	return string(digits)
+}
+
+var anyUUIDPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// normalizeUUIDs replaces UUIDs, which differ on every run, with a placeholder
+func normalizeUUIDs(s string) string {
+	return anyUUIDPattern.ReplaceAllString(s, "{uuid}")
+}
+
+// matchKey identifies a request by method, path, query and body
+func matchKey(r RecordedRequest) string {
+	return r.Method + " " + normalizeUUIDs(r.Path) + "?" + r.Query + " " + normalizeUUIDs(r.Body)
+}
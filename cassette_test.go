@@ -0,0 +1,304 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cassetteServer serves tokens, request-to-pay and account holder checks
+func cassetteServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /collection/token/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"live-token","token_type":"access_token","expires_in":3600}`)
+	})
+	mux.HandleFunc("POST /collection/v1_0/requesttopay", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /collection/v1_0/requesttopay/{referenceId}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"amount":"10.00","currency":"EUR","status":"SUCCESSFUL","payer":{"partyIdType":"MSISDN","partyId":"46733123454"}}`)
+	})
+	mux.HandleFunc("GET /collection/v1_0/accountholder/msisdn/{id}/active", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"result":%t}`, r.PathValue("id") == "46733123454")
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// cassetteClient creates a collection service using the cassette
+func cassetteClient(t *testing.T, baseURL string, cassette *Cassette) *CollectionService {
+	t.Helper()
+
+	config := newTestConfig(t, baseURL, WithInterceptors(cassette.Interceptor()))
+	client := NewClient(config)
+	return NewCollectionService(client, config, NewAuthService(client, config))
+}
+
+// recordCassette records a payment and two account holder checks
+func recordCassette(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette, err := NewCassette(path, Record)
+	if err != nil {
+		t.Fatalf("NewCassette: %v", err)
+	}
+	collection := cassetteClient(t, cassetteServer(t).URL, cassette)
+
+	ctx := context.Background()
+	referenceID, err := collection.RequestToPayMoney(ctx, "46733123454", NewMoney(1000, "EUR"), &RequestToPayOptions{ExternalID: "order-1"})
+	if err != nil {
+		t.Fatalf("RequestToPayMoney: %v", err)
+	}
+	if _, err := collection.GetTransactionStatus(ctx, referenceID); err != nil {
+		t.Fatalf("GetTransactionStatus: %v", err)
+	}
+	for _, msisdn := range []string{"46733123454", "46733123450"} {
+		if _, err := collection.IsAccountHolderActive(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: msisdn}); err != nil {
+			t.Fatalf("IsAccountHolderActive: %v", err)
+		}
+	}
+
+	if err := cassette.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return path
+}
+
+func TestCassetteRecordScrubsCredentialsAndMSISDNs(t *testing.T) {
+	data, err := os.ReadFile(recordCassette(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := string(data)
+
+	for _, secret := range []string{"live-token", "secret", `"key"`, "Basic ", "46733123454", "46733123450"} {
+		if strings.Contains(file, secret) {
+			t.Errorf("cassette contains %s", secret)
+		}
+	}
+
+	cassette := &Cassette{key: []byte(defaultCassetteKey)}
+	for _, kept := range []string{
+		`"partyId\":\"` + cassette.pseudonym("46733123454") + `\"`, // request and response bodies
+		"/accountholder/msisdn/" + cassette.pseudonym("46733123450") + "/active",
+		`order-1`,
+	} {
+		if !strings.Contains(file, kept) {
+			t.Errorf("cassette does not contain %s", kept)
+		}
+	}
+}
+
+func TestCassetteScrubBody(t *testing.T) {
+	cassette := &Cassette{key: []byte(defaultCassetteKey)}
+	pseudonym := cassette.pseudonym("46733123454")
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"non-JSON", `not json 46733123454`, `not json 46733123454`},
+		{"secret", `{"apiKey":"secret"}`, `{"apiKey":"[REDACTED]"}`},
+		{
+			"MSISDN party",
+			`{"payer":{"partyIdType":"MSISDN","partyId":"46733123454"}}`,
+			`{"payer":{"partyId":"` + pseudonym + `","partyIdType":"MSISDN"}}`,
+		},
+		{
+			"email party",
+			`{"payer":{"partyIdType":"EMAIL","partyId":"payer@example.com"}}`,
+			`{"payer":{"partyId":"payer@example.com","partyIdType":"EMAIL"}}`,
+		},
+		{"payerMsisdn", `{"payerMsisdn":"46733123454"}`, `{"payerMsisdn":"` + pseudonym + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cassette.scrubBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("scrubBody(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCassettePseudonym(t *testing.T) {
+	cassette := &Cassette{key: []byte(defaultCassetteKey)}
+	other := &Cassette{key: []byte("other key")}
+
+	pseudonym := cassette.pseudonym("46733123454")
+	if len(pseudonym) != len("46733123454") || strings.Trim(pseudonym, "0123456789") != "" {
+		t.Errorf("pseudonym %q is not an 11 digit number", pseudonym)
+	}
+	if cassette.pseudonym("46733123454") != pseudonym {
+		t.Error("pseudonym is not stable")
+	}
+	if cassette.pseudonym("46733123450") == pseudonym {
+		t.Error("different numbers share a pseudonym")
+	}
+	if other.pseudonym("46733123454") == pseudonym {
+		t.Error("pseudonym does not depend on the key")
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	path := recordCassette(t)
+
+	cassette, err := NewCassette(path, Replay)
+	if err != nil {
+		t.Fatalf("NewCassette: %v", err)
+	}
+	// Nothing listens here, so every response must come from the cassette
+	collection := cassetteClient(t, "http://momo.invalid", cassette)
+
+	ctx := context.Background()
+	referenceID, err := collection.RequestToPayMoney(ctx, "46733123454", NewMoney(1000, "EUR"), &RequestToPayOptions{ExternalID: "order-1"})
+	if err != nil {
+		t.Fatalf("RequestToPayMoney: %v", err)
+	}
+	status, err := collection.GetTransactionStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("GetTransactionStatus: %v", err)
+	}
+	// Responses are replayed with the pseudonym of the payer
+	if status.Status != Successful || status.Payer.PartyID != cassette.pseudonym("46733123454") {
+		t.Errorf("replayed status = %s, payer %q", status.Status, status.Payer.PartyID)
+	}
+
+	tests := []struct {
+		msisdn string
+		want   bool
+	}{
+		{"46733123450", false},
+		{"46733123454", true},
+	}
+	for _, tt := range tests {
+		active, err := collection.IsAccountHolderActive(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: tt.msisdn})
+		if err != nil || active != tt.want {
+			t.Errorf("IsAccountHolderActive(%s) = %v, %v; want %v", tt.msisdn, active, err, tt.want)
+		}
+	}
+
+	if err := cassette.Err(); err != nil {
+		t.Errorf("Err: %v", err)
+	}
+}
+
+func TestCassetteMismatch(t *testing.T) {
+	path := recordCassette(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func(*CollectionService) error
+		wantMsg string
+	}{
+		{"different amount", func(c *CollectionService) error {
+			_, err := c.RequestToPayMoney(ctx, "46733123454", NewMoney(2000, "EUR"), &RequestToPayOptions{ExternalID: "order-1"})
+			return err
+		}, "body differs"},
+		{"different payer", func(c *CollectionService) error {
+			_, err := c.RequestToPayMoney(ctx, "46700000054", NewMoney(1000, "EUR"), &RequestToPayOptions{ExternalID: "order-1"})
+			return err
+		}, "body differs"},
+		{"unrecorded account holder", func(c *CollectionService) error {
+			_, err := c.IsAccountHolderActive(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: "46700000050"})
+			return err
+		}, "no interaction recorded"},
+		{"replayed twice", func(c *CollectionService) error {
+			for i := 0; i < 2; i++ {
+				if _, err := c.IsAccountHolderActive(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: "46733123450"}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, "already replayed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cassette, err := NewCassette(path, Replay)
+			if err != nil {
+				t.Fatalf("NewCassette: %v", err)
+			}
+			collection := cassetteClient(t, "http://momo.invalid", cassette)
+
+			err = tt.call(collection)
+			if !errors.Is(err, ErrCassetteMismatch) {
+				t.Fatalf("error = %v, want ErrCassetteMismatch", err)
+			}
+			if len(cassette.Mismatches()) != 1 {
+				t.Errorf("recorded %d mismatches, want 1", len(cassette.Mismatches()))
+			}
+			if err := cassette.Err(); err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Err = %v, want it to mention %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestWithCassetteKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"", defaultCassetteKey},
+		{"secret key", "secret key"},
+	}
+
+	for _, tt := range tests {
+		cassette, err := NewCassette(filepath.Join(t.TempDir(), "cassette.json"), Record, WithCassetteKey(tt.key))
+		if err != nil {
+			t.Fatalf("NewCassette: %v", err)
+		}
+		if string(cassette.key) != tt.want {
+			t.Errorf("WithCassetteKey(%q) key = %q, want %q", tt.key, cassette.key, tt.want)
+		}
+	}
+}
+
+func TestCassetteReplayWithOtherKey(t *testing.T) {
+	cassette, err := NewCassette(recordCassette(t), Replay, WithCassetteKey("other key"))
+	if err != nil {
+		t.Fatalf("NewCassette: %v", err)
+	}
+	collection := cassetteClient(t, "http://momo.invalid", cassette)
+
+	_, err = collection.IsAccountHolderActive(context.Background(), PartyInfo{PartyIDType: MSISDN, PartyID: "46733123454"})
+	if !errors.Is(err, ErrCassetteMismatch) {
+		t.Fatalf("error = %v, want ErrCassetteMismatch", err)
+	}
+	if strings.Contains(err.Error(), "46733123454") {
+		t.Errorf("mismatch %q contains the MSISDN", err)
+	}
+}
+
+func TestNewCassetteErrors(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing.json"), corrupt} {
+		if _, err := NewCassette(path, Replay); err == nil {
+			t.Errorf("NewCassette(%s) succeeded", filepath.Base(path))
+		}
+	}
+
+	// Record mode does not need the file to exist
+	if _, err := NewCassette(filepath.Join(dir, "new", "cassette.json"), Record); err != nil {
+		t.Errorf("NewCassette in Record mode: %v", err)
+	}
+}
@@ -5,26 +5,58 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"strings"
 	"time"
 )
 
 // Client handles HTTP communication with the MTN MoMo API
 type Client struct {
-	config     *Config
-	httpClient *http.Client
+	config      *Config
+	httpClient  *http.Client
+	retryBudget *retryBudget
+	invoke      Invoker
+
+	// Called with the product whose bearer token the API rejected
+	onUnauthorized func(product string)
 }
 
 // NewClient creates a new MTN MoMo API client
 func NewClient(config *Config) *Client {
-	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	c := &Client{
+		config:      config,
+		httpClient:  newHTTPClient(config),
+		retryBudget: newRetryBudget(config.RetryPolicy),
+	}
+	interceptors := config.Interceptors
+	if config.Logger != nil {
+		// Log innermost so the request is logged exactly as it is sent
+		interceptors = append(interceptors[:len(interceptors):len(interceptors)], loggingInterceptor(config.Logger))
 	}
+	c.invoke = chainInterceptors(interceptors, c.send)
+	return c
+}
+
+// newHTTPClient builds the HTTP client from the configured client and transport
+func newHTTPClient(config *Config) *http.Client {
+	if config.HTTPClient == nil {
+		return &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: config.Transport,
+		}
+	}
+	if config.Transport == nil {
+		return config.HTTPClient
+	}
+
+	// Copy so the caller's client is left untouched
+	httpClient := *config.HTTPClient
+	httpClient.Transport = config.Transport
+	return &httpClient
 }
 
 // Request represents an HTTP request to the API
@@ -34,20 +66,94 @@ type Request struct {
 	Body        interface{}
 	Headers     map[string]string
 	QueryParams map[string]string
+	Idempotent  bool // Safe to retry even though the method is not idempotent
 }
 
-// DoRequest performs an HTTP request and decodes the response
+// clone copies the request so that changes to its maps do not leak back
+func (r Request) clone() Request {
+	r.Headers = maps.Clone(r.Headers)
+	r.QueryParams = maps.Clone(r.QueryParams)
+	return r
+}
+
+// DoRequest performs an HTTP request and decodes the response. Failed
+// attempts are retried according to the configured RetryPolicy when the
+// request is safe to send again.
 func (c *Client) DoRequest(ctx context.Context, req Request, result interface{}) error {
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if req.Body != nil {
-		bodyBytes, err := json.Marshal(req.Body)
+		var err error
+		bodyBytes, err = json.Marshal(req.Body)
 		if err != nil {
 			return fmt.Errorf("error marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewBuffer(bodyBytes)
 	}
 
-	url := fmt.Sprintf("https://%s%s", c.config.Host, req.Path)
+	policy := c.config.RetryPolicy
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 || !req.isIdempotent() {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.doAttempt(ctx, req, bodyBytes, attempt, result)
+		if err == nil {
+			c.retryBudget.deposit()
+			return nil
+		}
+
+		// A conflict on a resent reference ID means an earlier attempt
+		// that we saw fail was in fact accepted
+		if attempt > 1 && req.referenceID() != "" && errors.Is(err, ErrDuplicateReferenceID) {
+			c.retryBudget.deposit()
+			return nil
+		}
+
+		if attempt >= maxAttempts || !isRetryableError(err) {
+			c.notifyUnauthorized(req, err)
+			return err
+		}
+
+		delay := policy.retryDelay(attempt, err)
+
+		// Give up early if the context would expire before the next attempt
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+
+		if !c.retryBudget.withdraw() {
+			return err
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// notifyUnauthorized reports a rejected bearer token so it is not reused
+func (c *Client) notifyUnauthorized(req Request, err error) {
+	var momoErr *MoMoError
+	if c.onUnauthorized == nil || !errors.As(err, &momoErr) || momoErr.StatusCode != http.StatusUnauthorized {
+		return
+	}
+	if !strings.HasPrefix(req.Headers["Authorization"], "Bearer ") {
+		return
+	}
+
+	// Paths start with the product, e.g. /collection/v1_0/requesttopay
+	product, _, _ := strings.Cut(strings.TrimPrefix(req.Path, "/"), "/")
+	c.onUnauthorized(product)
+}
+
+// doAttempt sends a single HTTP request and decodes the response
+func (c *Client) doAttempt(ctx context.Context, req Request, bodyBytes []byte, attempt int, result interface{}) error {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	url := c.config.APIBaseURL() + req.Path
 	httpReq, err := http.NewRequestWithContext(ctx, req.Method, url, bodyReader)
 	if err != nil {
 		return fmt.Errorf("error creating HTTP request: %w", err)
@@ -70,27 +176,44 @@ func (c *Client) DoRequest(ctx context.Context, req Request, result interface{})
 		httpReq.URL.RawQuery = q.Encode()
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	call := &Call{
+		Request:     req.clone(),
+		HTTPRequest: httpReq,
+		Attempt:     attempt,
+	}
+
+	resp, err := c.invoke(call)
 	if err != nil {
 		return fmt.Errorf("error making HTTP request: %w", err)
 	}
+	if resp == nil {
+		return fmt.Errorf("%w: no HTTP response", ErrInvalidResponse)
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(bodyBytes))
+		return parseMoMoError(resp.StatusCode, resp.Header, bodyBytes)
 	}
 
 	// Only try to decode if we have a result pointer and the response isn't empty
 	if result != nil && resp.StatusCode != http.StatusNoContent {
 		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("error decoding response: %w", err)
+			return fmt.Errorf("%w: error decoding response: %w", ErrInvalidResponse, err)
 		}
 	}
 
 	return nil
 }
 
+// send is the innermost invoker, performing the HTTP round trip
+func (c *Client) send(call *Call) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(call.HTTPRequest)
+	call.Latency = time.Since(start)
+	return resp, err
+}
+
 // CreateBasicAuthHeader generates a Basic Auth header from API user and key
 func CreateBasicAuthHeader(apiUser, apiKey string) string {
 	auth := fmt.Sprintf("%s:%s", apiUser, apiKey)
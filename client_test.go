@@ -0,0 +1,136 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRequestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel error
+	}{
+		{"bad request", http.StatusBadRequest, `{"code":"INVALID_AMOUNT","message":"bad amount"}`, ErrInvalidRequest},
+		{"not found", http.StatusNotFound, `{"code":"RESOURCE_NOT_FOUND","message":"missing"}`, ErrNotFound},
+		{"conflict", http.StatusConflict, `{"code":"RESOURCE_ALREADY_EXIST","message":"dup"}`, ErrDuplicateReferenceID},
+		{"gateway error", http.StatusUnauthorized, `{"statusCode":401,"message":"invalid key"}`, ErrAuthenticationFailed},
+		{"server error", http.StatusInternalServerError, `not json`, ErrAPIRequestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			client := NewClient(newTestConfig(t, srv.URL))
+			err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+			var momoErr *MoMoError
+			if !errors.As(err, &momoErr) {
+				t.Fatalf("error %v is not a *MoMoError", err)
+			}
+			if momoErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", momoErr.StatusCode, tt.status)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+		})
+	}
+}
+
+func TestDoRequestDecodeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"a": tru}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(newTestConfig(t, srv.URL))
+	var result map[string]string
+	err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, &result)
+
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("errors.Is(%v, ErrInvalidResponse) = false", err)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("errors.As(%v, *json.SyntaxError) = false", err)
+	}
+}
+
+// countingTransport counts the requests it carries
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHTTPClientOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+	}{
+		{"transport only", nil},
+		{"client and transport", &http.Client{Timeout: 5 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &countingTransport{}
+			opts := []ConfigOption{WithTransport(transport)}
+			if tt.httpClient != nil {
+				opts = append(opts, WithHTTPClient(tt.httpClient))
+			}
+
+			client := NewClient(newTestConfig(t, srv.URL, opts...))
+			if err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, nil); err != nil {
+				t.Fatalf("DoRequest: %v", err)
+			}
+
+			if got := transport.requests.Load(); got != 1 {
+				t.Errorf("transport carried %d requests, want 1", got)
+			}
+			if tt.httpClient == nil {
+				return
+			}
+			if tt.httpClient.Transport != nil {
+				t.Error("caller's http.Client transport was modified")
+			}
+			if client.httpClient == tt.httpClient {
+				t.Error("caller's http.Client is used directly instead of a copy")
+			}
+			if client.httpClient.Timeout != tt.httpClient.Timeout {
+				t.Errorf("Timeout = %v, want %v", client.httpClient.Timeout, tt.httpClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestHTTPClientOptionUsedAsIs(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+
+	client := NewClient(newTestConfig(t, "http://example.invalid", WithHTTPClient(httpClient)))
+	if client.httpClient != httpClient {
+		t.Error("http.Client without a transport override was not used as is")
+	}
+}
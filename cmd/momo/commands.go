@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// sandbox runs the sandbox subcommands
+func (a *app) sandbox(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "provision" {
+		fmt.Fprintln(a.stderr, "Usage: momo sandbox provision")
+		return errUsage
+	}
+	fs := a.flagSet("sandbox provision", "")
+	if _, err := parseArgs(fs, args[1:], 0); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	// Create API user
+	apiUser, err := client.Auth.CreateAPIUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Create API key
+	apiKey, err := client.Auth.CreateAPIKey(ctx, apiUser)
+	if err != nil {
+		return err
+	}
+
+	if a.output == "json" {
+		return a.printJSON(map[string]string{"apiUser": apiUser, "apiKey": apiKey})
+	}
+	fmt.Fprintf(a.stdout, "export MOMO_API_USER=%s\n", apiUser)
+	fmt.Fprintf(a.stdout, "export MOMO_API_KEY=%s\n", apiKey)
+	return nil
+}
+
+// paymentFlags are the flags shared by collect and transfer. The currency is
+// the configured one, set with the global -currency flag.
+type paymentFlags struct {
+	phone        *string
+	amount       *string
+	externalID   *string
+	payerMessage *string
+	payeeNote    *string
+	wait         *bool
+}
+
+func addPaymentFlags(fs *flag.FlagSet) paymentFlags {
+	return paymentFlags{
+		phone:        fs.String("phone", "", "subscriber MSISDN (required)"),
+		amount:       fs.String("amount", "", "amount in major units, e.g. 10.50 (required)"),
+		externalID:   fs.String("external-id", "", "external ID (generated if empty)"),
+		payerMessage: fs.String("message", "", "message to the payer"),
+		payeeNote:    fs.String("note", "", "note to the payee"),
+		wait:         fs.Bool("wait", false, "wait for the final status"),
+	}
+}
+
+// money parses the amount flags
+func (f paymentFlags) money(config *gomomo.Config) (gomomo.Money, error) {
+	if *f.phone == "" || *f.amount == "" {
+		return gomomo.Money{}, fmt.Errorf("%w: -phone and -amount are required", errUsage)
+	}
+	return gomomo.ParseMoney(*f.amount, config.Currency)
+}
+
+// collect requests a payment
+func (a *app) collect(ctx context.Context, args []string) error {
+	fs := a.flagSet("collect", "")
+	flags := addPaymentFlags(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	amount, err := flags.money(client.Config)
+	if err != nil {
+		return err
+	}
+
+	referenceID, err := client.Collection.RequestToPayMoney(ctx, *flags.phone, amount, &gomomo.RequestToPayOptions{
+		ExternalID:   *flags.externalID,
+		PayerMessage: *flags.payerMessage,
+		PayeeNote:    *flags.payeeNote,
+	})
+	if err != nil {
+		return err
+	}
+	if !*flags.wait {
+		return a.printReference(referenceID)
+	}
+
+	status, err := client.Collection.WaitForFinalStatus(ctx, referenceID, nil)
+	return a.printFinalStatus(referenceID, status, err)
+}
+
+// transfer sends money
+func (a *app) transfer(ctx context.Context, args []string) error {
+	fs := a.flagSet("transfer", "")
+	flags := addPaymentFlags(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	amount, err := flags.money(client.Config)
+	if err != nil {
+		return err
+	}
+
+	referenceID, err := client.Disbursement.TransferMoney(ctx, *flags.phone, amount, &gomomo.TransferOptions{
+		ExternalID:   *flags.externalID,
+		PayerMessage: *flags.payerMessage,
+		PayeeNote:    *flags.payeeNote,
+	})
+	if err != nil {
+		return err
+	}
+	if !*flags.wait {
+		return a.printReference(referenceID)
+	}
+
+	status, err := client.Disbursement.WaitForFinalStatus(ctx, referenceID, nil)
+	return a.printFinalStatus(referenceID, status, err)
+}
+
+// status checks a transaction's status
+func (a *app) status(ctx context.Context, args []string) error {
+	fs := a.flagSet("status", "<ref>")
+	product := productFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	referenceID := positional[0]
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var status *gomomo.TransactionStatusResponse
+	switch *product {
+	case "collection":
+		status, err = client.Collection.GetTransactionStatus(ctx, referenceID)
+	case "disbursement":
+		status, err = client.Disbursement.GetTransferStatus(ctx, referenceID)
+	case "remittance":
+		status, err = client.Remittance.GetTransferStatus(ctx, referenceID)
+	default:
+		return unknownProduct(*product)
+	}
+	if err != nil {
+		return err
+	}
+	return a.printStatus(referenceID, status)
+}
+
+// balance shows the account balance
+func (a *app) balance(ctx context.Context, args []string) error {
+	fs := a.flagSet("balance", "")
+	product := productFlag(fs)
+	currency := fs.String("account-currency", "", "currency of the balance (defaults to the account's currency)")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var balances gomomo.BalanceChecker
+	switch *product {
+	case "collection":
+		balances = client.Collection
+	case "disbursement":
+		balances = client.Disbursement
+	case "remittance":
+		balances = client.Remittance
+	default:
+		return unknownProduct(*product)
+	}
+
+	var balance *gomomo.Balance
+	if *currency == "" {
+		balance, err = balances.GetBalance(ctx)
+	} else {
+		balance, err = balances.GetAccountBalanceInCurrency(ctx, *currency)
+	}
+	if err != nil {
+		return err
+	}
+
+	if a.output == "json" {
+		return a.printJSON(balance)
+	}
+	return a.printTable([][2]string{
+		{"Product", *product},
+		{"Available", balance.AvailableBalance},
+		{"Currency", balance.Currency},
+	})
+}
+
+// accountHolder shows an account holder's details and status. If the status
+// check fails, the status is reported as unknown: left out of the table and
+// null in JSON.
+func (a *app) accountHolder(ctx context.Context, args []string) error {
+	fs := a.flagSet("accountholder", "<msisdn>")
+	product := productFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	msisdn := positional[0]
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var holders gomomo.AccountHolderChecker
+	switch *product {
+	case "collection":
+		holders = client.Collection
+	case "disbursement":
+		holders = client.Disbursement
+	case "remittance":
+		holders = client.Remittance
+	default:
+		return unknownProduct(*product)
+	}
+
+	var active *bool
+	isActive, err := holders.IsAccountHolderActive(ctx, gomomo.PartyInfo{PartyIDType: gomomo.MSISDN, PartyID: msisdn})
+	if err != nil {
+		fmt.Fprintf(a.stderr, "momo: could not check whether %s is active: %v\n", msisdn, err)
+	} else {
+		active = &isActive
+	}
+
+	info, err := holders.GetAccountHolderInfo(ctx, msisdn)
+	if err != nil {
+		return err
+	}
+
+	if a.output == "json" {
+		return a.printJSON(struct {
+			MSISDN string `json:"msisdn"`
+			Active *bool  `json:"active"`
+			*gomomo.AccountHolderInfo
+		}{msisdn, active, info})
+	}
+
+	activeRow := ""
+	if active != nil {
+		activeRow = fmt.Sprint(*active)
+	}
+	return a.printTable([][2]string{
+		{"MSISDN", msisdn},
+		{"Active", activeRow},
+		{"Name", strings.TrimSpace(info.GivenName + " " + info.FamilyName)},
+		{"Birthdate", info.Birthdate},
+		{"Gender", info.Gender},
+		{"Locale", info.Locale},
+		{"Status", info.Status},
+	})
+}
+
+// token prints an access token
+func (a *app) token(ctx context.Context, args []string) error {
+	fs := a.flagSet("token", "")
+	product := productFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Auth.GetAccessToken(ctx, *product)
+	if err != nil {
+		return err
+	}
+
+	if a.output == "json" {
+		return a.printJSON(map[string]string{"product": *product, "accessToken": token})
+	}
+	fmt.Fprintln(a.stdout, token)
+	return nil
+}
+
+// flagSet creates the flag set of a command
+func (a *app) flagSet(command, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: momo %s [flags] %s\n\nFlags:\n", command, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// productFlag adds the -product flag
+func productFlag(fs *flag.FlagSet) *string {
+	return fs.String("product", "collection", "product: collection, disbursement or remittance")
+}
+
+func unknownProduct(product string) error {
+	return fmt.Errorf("%w: unknown product %q", errUsage, product)
+}
+
+// parseArgs parses flags that may appear before or after the positional
+// arguments, and checks that exactly want positional arguments were given
+func parseArgs(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, parseError(err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != want {
+		fs.Usage()
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// parseError maps flag parsing errors, which the flag package has already
+// printed, to errUsage
+func parseError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errUsage
+}
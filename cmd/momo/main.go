@@ -0,0 +1,189 @@
+// Command momo runs common MTN MoMo operations from the command line.
+//
+// Usage:
+//
+//	momo [flags] <command> [command flags] [arguments]
+//
+// Commands:
+//
+//	sandbox provision      create a sandbox API user and key and print env exports
+//	collect                request a payment from a subscriber
+//	transfer               send money to a subscriber
+//	status <ref>           check the status of a transaction
+//	balance                show the account balance
+//	accountholder <msisdn> show an account holder's details and status
+//	token                  print an access token
+//
+// Configuration is read from the MOMO_* environment variables (see
+// gomomo.FromEnv) and can be overridden with flags. MOMO_ENVIRONMENT selects
+// sandbox or production.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// errUsage reports bad command-line usage
+var errUsage = errors.New("invalid usage")
+
+// app holds the global flags shared by all commands
+type app struct {
+	environment       string
+	output            string
+	timeout           time.Duration
+	subscriptionKey   string
+	disbursementKey   string
+	remittanceKey     string
+	apiUser           string
+	apiKey            string
+	targetEnvironment string
+	baseURL           string
+	host              string
+	currency          string
+	callbackHost      string
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	a := &app{stdout: os.Stdout, stderr: os.Stderr}
+	err := a.run(os.Args[1:])
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case err == errUsage:
+		// Usage has already been printed
+		os.Exit(2)
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "momo: %v\n", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "momo: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and dispatches to a command
+func (a *app) run(args []string) error {
+	fs := flag.NewFlagSet("momo", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() { a.usage(fs) }
+
+	environment := os.Getenv("MOMO_ENVIRONMENT")
+	if environment == "" {
+		environment = string(gomomo.Sandbox)
+	}
+	fs.StringVar(&a.environment, "env", environment, "environment: sandbox or production")
+	fs.StringVar(&a.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&a.timeout, "timeout", 60*time.Second, "timeout for the whole command")
+	fs.StringVar(&a.subscriptionKey, "subscription-key", "", "collection subscription key")
+	fs.StringVar(&a.disbursementKey, "disbursement-key", "", "disbursement subscription key")
+	fs.StringVar(&a.remittanceKey, "remittance-key", "", "remittance subscription key")
+	fs.StringVar(&a.apiUser, "api-user", "", "API user")
+	fs.StringVar(&a.apiKey, "api-key", "", "API key")
+	fs.StringVar(&a.targetEnvironment, "target-environment", "", "X-Target-Environment, e.g. mtnuganda")
+	fs.StringVar(&a.baseURL, "base-url", "", "API base URL")
+	fs.StringVar(&a.host, "host", "", "API host")
+	fs.StringVar(&a.currency, "currency", "", "default currency")
+	fs.StringVar(&a.callbackHost, "callback-host", "", "callback host")
+
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if a.output != "table" && a.output != "json" {
+		fmt.Fprintf(a.stderr, "momo: unknown output format %q\n", a.output)
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+	switch command {
+	case "sandbox":
+		return a.sandbox(ctx, rest)
+	case "collect":
+		return a.collect(ctx, rest)
+	case "transfer":
+		return a.transfer(ctx, rest)
+	case "status":
+		return a.status(ctx, rest)
+	case "balance":
+		return a.balance(ctx, rest)
+	case "accountholder":
+		return a.accountHolder(ctx, rest)
+	case "token":
+		return a.token(ctx, rest)
+	default:
+		fmt.Fprintf(a.stderr, "momo: unknown command %q\n", command)
+		fs.Usage()
+		return errUsage
+	}
+}
+
+func (a *app) usage(fs *flag.FlagSet) {
+	fmt.Fprint(a.stderr, `Usage: momo [flags] <command> [command flags] [arguments]
+
+Commands:
+  sandbox provision       create a sandbox API user and key and print env exports
+  collect                 request a payment from a subscriber
+  transfer                send money to a subscriber
+  status <ref>            check the status of a transaction
+  balance                 show the account balance
+  accountholder <msisdn>  show an account holder's details and status
+  token                   print an access token
+
+Flags:
+`)
+	fs.PrintDefaults()
+}
+
+// client builds a MoMo client from the environment and flags
+func (a *app) client() (*gomomo.MoMoClient, error) {
+	opts := []gomomo.ConfigOption{gomomo.FromEnv()}
+
+	// Flags override the environment
+	overrides := []struct {
+		value  string
+		option func(string) gomomo.ConfigOption
+	}{
+		{a.subscriptionKey, gomomo.WithSubscriptionKey},
+		{a.disbursementKey, gomomo.WithDisbursementKey},
+		{a.remittanceKey, gomomo.WithRemittanceKey},
+		{a.apiUser, gomomo.WithAPIUser},
+		{a.apiKey, gomomo.WithAPIKey},
+		{a.targetEnvironment, gomomo.WithTargetEnvironment},
+		{a.baseURL, gomomo.WithBaseURL},
+		{a.host, gomomo.WithHost},
+		{a.currency, gomomo.WithCurrency},
+		{a.callbackHost, gomomo.WithCallbackHost},
+	}
+	for _, override := range overrides {
+		if override.value != "" {
+			opts = append(opts, override.option(override.value))
+		}
+	}
+
+	environment := gomomo.EnvironmentType(a.environment)
+	if environment != gomomo.Sandbox && environment != gomomo.Production {
+		return nil, fmt.Errorf("%w: unknown environment %q", errUsage, a.environment)
+	}
+
+	config, err := gomomo.NewConfig(environment, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gomomo.NewMoMoClient(config), nil
+}
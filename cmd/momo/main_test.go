@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sir-george2500/gomomo"
+	"github.com/sir-george2500/gomomo/momotest"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		want        int
+		wantArgs    []string
+		wantProduct string
+		wantErr     error
+	}{
+		{"flag before argument", []string{"-product", "disbursement", "ref-1"}, 1, []string{"ref-1"}, "disbursement", nil},
+		{"flag after argument", []string{"ref-1", "-product", "remittance"}, 1, []string{"ref-1"}, "remittance", nil},
+		{"default flag", []string{"ref-1"}, 1, []string{"ref-1"}, "collection", nil},
+		{"no arguments", nil, 0, nil, "collection", nil},
+		{"missing argument", []string{"-product", "remittance"}, 1, nil, "", errUsage},
+		{"extra argument", []string{"ref-1", "ref-2"}, 1, nil, "", errUsage},
+		{"unknown flag", []string{"-currency", "EUR", "ref-1"}, 1, nil, "", errUsage},
+		{"help", []string{"-h"}, 1, nil, "", flag.ErrHelp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{stdout: io.Discard, stderr: io.Discard}
+			fs := a.flagSet("status", "<ref>")
+			product := productFlag(fs)
+
+			got, err := parseArgs(fs, tt.args, tt.want)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("parseArgs error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantArgs) || *product != tt.wantProduct {
+				t.Errorf("parseArgs = %q, product %q; want %q, %q", got, *product, tt.wantArgs, tt.wantProduct)
+			}
+		})
+	}
+}
+
+// clearEnv stops the MOMO_* variables of the environment running the tests
+// from leaking into the configuration
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"MOMO_ENVIRONMENT", "MOMO_SUBSCRIPTION_KEY", "MOMO_DISBURSEMENT_KEY", "MOMO_REMITTANCE_KEY",
+		"MOMO_API_USER", "MOMO_API_KEY", "MOMO_TARGET_ENVIRONMENT", "MOMO_BASE_URL", "MOMO_HOST",
+		"MOMO_CURRENCY", "MOMO_CURRENCIES", "MOMO_COUNTRY", "MOMO_CALLBACK_HOST", "MOMO_CALLBACK_PATH",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+// runMomo runs the command against a server and returns its output
+func runMomo(t *testing.T, baseURL string, args ...string) (string, string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	a := &app{stdout: &stdout, stderr: &stderr}
+	global := []string{
+		"-subscription-key", "test-key",
+		"-disbursement-key", "test-key",
+		"-remittance-key", "test-key",
+		"-target-environment", "sandbox",
+		"-base-url", baseURL,
+	}
+	err := a.run(append(global, args...))
+	return stdout.String(), stderr.String(), err
+}
+
+func TestRun(t *testing.T) {
+	clearEnv(t)
+
+	srv := momotest.NewServer(
+		momotest.WithOutcomes(momotest.SandboxNumbers()),
+		momotest.WithAccountBalance("disbursement", gomomo.NewMoney(10000, "EUR")),
+		momotest.WithAccountBalance("disbursement", gomomo.NewMoney(2500, "USD")),
+	)
+	defer srv.Close()
+	srv.AddWallet(momotest.Wallet{
+		MSISDN:  "256772123456",
+		Balance: gomomo.NewMoney(5000, "EUR"),
+		Info:    gomomo.AccountHolderInfo{GivenName: "Jane", FamilyName: "Doe"},
+	})
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    error
+		wantStdout []string
+	}{
+		{"collect and wait", []string{"collect", "-phone", "256772123456", "-amount", "10.50", "-wait"}, nil, []string{"Status:", "SUCCESSFUL", "10.50 EUR"}},
+		{"failed transfer", []string{"transfer", "-phone", "46733123450", "-amount", "1", "-wait"}, gomomo.ErrTransactionFailed, []string{"FAILED", "INTERNAL_PROCESSING_ERROR"}},
+		{"balance", []string{"balance", "-product", "disbursement"}, nil, []string{"100.00", "EUR"}},
+		{"balance in currency", []string{"-o", "json", "balance", "-product", "disbursement", "-account-currency", "USD"}, nil, []string{`"availableBalance": "25.00"`, `"currency": "USD"`}},
+		{"account holder", []string{"accountholder", "256772123456"}, nil, []string{"Active:", "true", "Jane Doe"}},
+		{"unknown account holder", []string{"accountholder", "256772000000"}, gomomo.ErrNotFound, nil},
+		{"per-command currency flag", []string{"collect", "-phone", "256772123456", "-amount", "1", "-currency", "EUR"}, errUsage, nil},
+		{"missing amount", []string{"collect", "-phone", "256772123456"}, errUsage, nil},
+		{"unknown product", []string{"balance", "-product", "savings"}, errUsage, nil},
+		{"unknown command", []string{"refund"}, errUsage, nil},
+		{"unknown output", []string{"-o", "yaml", "balance"}, errUsage, nil},
+		{"no command", nil, errUsage, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, err := runMomo(t, srv.URL, tt.args...)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("run error = %v, want %v\nstderr: %s", err, tt.wantErr, stderr)
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("stdout does not contain %q:\n%s", want, stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestGlobalCurrency(t *testing.T) {
+	clearEnv(t)
+
+	srv := momotest.NewServer(momotest.WithCurrency("UGX"))
+	defer srv.Close()
+	srv.AddWallet(momotest.Wallet{MSISDN: "256772123456", Balance: gomomo.NewMoney(10000, "UGX")})
+
+	if _, stderr, err := runMomo(t, srv.URL, "-currency", "UGX", "collect", "-phone", "256772123456", "-amount", "5000"); err != nil {
+		t.Fatalf("run: %v\nstderr: %s", err, stderr)
+	}
+
+	transactions := srv.Transactions()
+	if len(transactions) != 1 || transactions[0].Amount != gomomo.NewMoney(5000, "UGX") {
+		t.Errorf("transactions = %+v, want one of 5000 UGX", transactions)
+	}
+}
+
+func TestAccountHolderActiveCheckFails(t *testing.T) {
+	clearEnv(t)
+
+	srv := momotest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(momotest.Wallet{MSISDN: "256772123456", Info: gomomo.AccountHolderInfo{GivenName: "Jane"}})
+
+	// Fail the active check and serve everything else from the fake API
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/active") {
+			http.Error(w, `{"code":"NOT_ALLOWED","message":"Not allowed"}`, http.StatusBadRequest)
+			return
+		}
+		srv.Server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	stdout, stderr, err := runMomo(t, proxy.URL, "-o", "json", "accountholder", "256772123456")
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decoding %s: %v", stdout, err)
+	}
+	if active, ok := result["active"]; !ok || active != nil {
+		t.Errorf("active = %v (present %v), want null", active, ok)
+	}
+	if result["given_name"] != "Jane" || result["msisdn"] != "256772123456" {
+		t.Errorf("result = %v, want Jane", result)
+	}
+	if !strings.Contains(stderr, "could not check whether 256772123456 is active") {
+		t.Errorf("stderr = %q, want a note about the failed check", stderr)
+	}
+
+	stdout, _, err = runMomo(t, proxy.URL, "accountholder", "256772123456")
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if strings.Contains(stdout, "Active") || !strings.Contains(stdout, "Jane") {
+		t.Errorf("table = %q, want Jane without an Active row", stdout)
+	}
+}
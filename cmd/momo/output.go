@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// printJSON writes a value as indented JSON
+func (a *app) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// printTable writes rows of label/value pairs, skipping empty values
+func (a *app) printTable(rows [][2]string) error {
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+	}
+	return w.Flush()
+}
+
+// printReference writes the reference ID of a new transaction
+func (a *app) printReference(referenceID string) error {
+	if a.output == "json" {
+		return a.printJSON(map[string]string{"referenceId": referenceID})
+	}
+	return a.printTable([][2]string{{"Reference", referenceID}})
+}
+
+// printStatus writes a transaction status
+func (a *app) printStatus(referenceID string, status *gomomo.TransactionStatusResponse) error {
+	if a.output == "json" {
+		return a.printJSON(struct {
+			ReferenceID string `json:"referenceId"`
+			*gomomo.TransactionStatusResponse
+		}{referenceID, status})
+	}
+
+	party := status.Payer
+	if party.PartyID == "" {
+		party = status.Payee
+	}
+	return a.printTable([][2]string{
+		{"Reference", referenceID},
+		{"Status", string(status.Status)},
+		{"Reason", status.Reason},
+		{"Amount", status.Amount + " " + status.Currency},
+		{"Party", party.PartyID},
+		{"External ID", status.ExternalID},
+		{"Financial ID", status.FinancialTransactionID},
+	})
+}
+
+// printFinalStatus writes the outcome of waiting for a transaction. Failed
+// transactions are printed before their error is returned.
+func (a *app) printFinalStatus(referenceID string, status *gomomo.TransactionStatusResponse, err error) error {
+	if status == nil {
+		return err
+	}
+	if printErr := a.printStatus(referenceID, status); printErr != nil {
+		return printErr
+	}
+	return err
+}
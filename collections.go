@@ -13,11 +13,11 @@ import (
 type CollectionService struct {
 	client      *Client
 	config      *Config
-	authService *AuthService
+	authService TokenProvider
 }
 
 // NewCollectionService creates a new collection service
-func NewCollectionService(client *Client, config *Config, authService *AuthService) *CollectionService {
+func NewCollectionService(client *Client, config *Config, authService TokenProvider) *CollectionService {
 	return &CollectionService{
 		client:      client,
 		config:      config,
@@ -29,27 +29,77 @@ type RequestToPayOptions struct {
 	IdempotencyKey string // Custom idempotency key (generated if empty)
 	ExternalID     string // Custom external ID (generated if empty)
 	ReferenceID    string // Custom reference ID (generated if empty)
-	Currency       string // Override default currency
+	Currency       string // Override default currency (amounts without a currency only)
 	PayerMessage   string // Message to the payer
 	PayeeNote      string // Note to the payee
+	CallbackURL    string // Callback URL (built from the config's callback path if empty)
+	PreApprovalID  string // Pre-approval the payment is made under, checked before sending
+}
+
+// RequestToWithdrawOptions contains optional parameters for withdrawal requests
+type RequestToWithdrawOptions struct {
+	IdempotencyKey string     // Custom idempotency key (generated if empty)
+	ExternalID     string     // Custom external ID (generated if empty)
+	ReferenceID    string     // Custom reference ID (generated if empty)
+	Currency       string     // Override default currency (amounts without a currency only)
+	PayerMessage   string     // Message to the payer
+	PayeeNote      string     // Note to the payee
+	CallbackURL    string     // Callback URL (built from the config's callback path if empty)
+	Version        APIVersion // Endpoint version (V1 if empty)
 }
 
 // RequestToPay initiates a payment request
+//
+// Deprecated: float amounts are rounded to the currency's minor unit. Use
+// RequestToPayMoney instead.
 func (s *CollectionService) RequestToPay(ctx context.Context, phone string, amount float64, opts *RequestToPayOptions) (string, error) {
-	// Format phone number if needed
-	phone = formatPhoneNumber(phone)
-
-	// Get access token
-	token, err := s.authService.GetAccessToken(ctx, "collection")
+	money, err := s.floatMoney(amount, opts)
 	if err != nil {
-		return "", fmt.Errorf("error getting access token: %w", err)
+		return "", err
 	}
+	return s.RequestToPayMoney(ctx, phone, money, opts)
+}
+
+// RequestToPayMoney initiates a payment request for an exact amount. An
+// amount without a currency is taken in opts.Currency, or the configured
+// default currency.
+func (s *CollectionService) RequestToPayMoney(ctx context.Context, phone string, amount Money, opts *RequestToPayOptions) (string, error) {
+	return s.RequestToPayFromParty(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: phone}, amount, opts)
+}
 
+// RequestToPayFromParty initiates a payment request for an exact amount from
+// a payer identified by MSISDN, email or party code. The currency of the
+// amount is resolved as in RequestToPayMoney.
+func (s *CollectionService) RequestToPayFromParty(ctx context.Context, payer PartyInfo, amount Money, opts *RequestToPayOptions) (string, error) {
 	// Use provided options or create defaults
 	if opts == nil {
 		opts = &RequestToPayOptions{}
 	}
 
+	amount, err := s.amountInCurrency(amount, opts.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	// Validate and normalize the payer
+	payer, err = s.config.normalizeParty(payer)
+	if err != nil {
+		return "", err
+	}
+
+	// Make sure the payer's pre-approval covers this payment
+	if opts.PreApprovalID != "" {
+		if err := s.checkPreApproval(ctx, opts.PreApprovalID, payer, amount.Currency); err != nil {
+			return "", err
+		}
+	}
+
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return "", fmt.Errorf("error getting access token: %w", err)
+	}
+
 	// Generate or use provided reference ID
 	referenceID := opts.ReferenceID
 	if referenceID == "" {
@@ -62,21 +112,12 @@ func (s *CollectionService) RequestToPay(ctx context.Context, phone string, amou
 		externalID = uuid.New().String()
 	}
 
-	// Use provided currency or default
-	currency := opts.Currency
-	if currency == "" {
-		currency = s.config.Currency
-	}
-
 	// Create request payload
 	payload := RequestToPayPayload{
-		Amount:     fmt.Sprintf("%.2f", amount),
-		Currency:   currency,
-		ExternalID: externalID,
-		Payer: PartyInfo{
-			PartyIDType: MSISDN,
-			PartyID:     phone,
-		},
+		Amount:       amount.AmountString(),
+		Currency:     amount.Currency,
+		ExternalID:   externalID,
+		Payer:        payer,
 		PayerMessage: defaultIfEmpty(opts.PayerMessage, "Payment request"),
 		PayeeNote:    defaultIfEmpty(opts.PayeeNote, "Thank you for your payment"),
 	}
@@ -94,6 +135,15 @@ func (s *CollectionService) RequestToPay(ctx context.Context, phone string, amou
 		headers["X-Idempotency-Key"] = opts.IdempotencyKey
 	}
 
+	// Add callback URL if provided or configured
+	callbackURL, err := s.config.callbackURL(CallbackRequestToPay, referenceID, opts.CallbackURL)
+	if err != nil {
+		return "", err
+	}
+	if callbackURL != "" {
+		headers["X-Callback-Url"] = callbackURL
+	}
+
 	// Make the request
 	req := Request{
 		Method:  http.MethodPost,
@@ -137,19 +187,101 @@ func (s *CollectionService) GetTransactionStatus(ctx context.Context, referenceI
 	return &result, nil
 }
 
+// WaitForFinalStatus polls the status of a payment request until it is no
+// longer pending or the context expires. If the payment failed, was rejected
+// or timed out, the final status is returned along with a *TransactionError.
+func (s *CollectionService) WaitForFinalStatus(ctx context.Context, referenceID string, opts *WaitOptions) (*TransactionStatusResponse, error) {
+	return waitForFinalStatus(ctx, referenceID, opts, s.GetTransactionStatus)
+}
+
+// RequestToWithdraw asks an account holder to approve a cash-out of an exact
+// amount from their wallet. An amount without a currency is taken in
+// opts.Currency, or the configured default currency.
+func (s *CollectionService) RequestToWithdraw(ctx context.Context, phone string, amount Money, opts *RequestToWithdrawOptions) (string, error) {
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &RequestToWithdrawOptions{}
+	}
+
+	amount, err := s.amountInCurrency(amount, opts.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := versionedPath("/collection/%s/requesttowithdraw", opts.Version)
+	if err != nil {
+		return "", err
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	// Create request payload
+	payload := RequestToPayPayload{
+		Amount:     amount.AmountString(),
+		Currency:   amount.Currency,
+		ExternalID: externalID,
+		Payer: PartyInfo{
+			PartyIDType: MSISDN,
+			PartyID:     msisdn,
+		},
+		PayerMessage: defaultIfEmpty(opts.PayerMessage, "Withdrawal request"),
+		PayeeNote:    defaultIfEmpty(opts.PayeeNote, "Cash withdrawal"),
+	}
+
+	err = s.post(ctx, path, payload, referenceID, opts.IdempotencyKey, CallbackRequestToWithdraw, opts.CallbackURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error making request-to-withdraw: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetWithdrawStatus checks the status of a withdrawal request
+func (s *CollectionService) GetWithdrawStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	var result TransactionStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/collection/v1_0/requesttowithdraw/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking withdrawal status: %w", err)
+	}
+
+	return &result, nil
+}
+
 // GetAccountBalance gets the balance of the account
 func (s *CollectionService) GetAccountBalance(ctx context.Context) (string, string, error) {
-	// Get access token
-	token, err := s.authService.GetAccessToken(ctx, "collection")
+	balance, err := s.GetBalance(ctx)
 	if err != nil {
-		return "", "", fmt.Errorf("error getting access token: %w", err)
+		return "", "", err
 	}
 
-	var result struct {
-		AvailableBalance string `json:"availableBalance"`
-		Currency         string `json:"currency"`
+	return balance.AvailableBalance, balance.Currency, nil
+}
+
+// GetBalance gets the balance of the account
+func (s *CollectionService) GetBalance(ctx context.Context) (*Balance, error) {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return nil, fmt.Errorf("error getting access token: %w", err)
 	}
 
+	var result Balance
 	req := Request{
 		Method: http.MethodGet,
 		Path:   "/collection/v1_0/account/balance",
@@ -162,16 +294,56 @@ func (s *CollectionService) GetAccountBalance(ctx context.Context) (string, stri
 
 	err = s.client.DoRequest(ctx, req, &result)
 	if err != nil {
-		return "", "", fmt.Errorf("error getting account balance: %w", err)
+		return nil, fmt.Errorf("error getting account balance: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetAccountBalanceInCurrency gets the balance of the account in a specific
+// currency, for wallets holding several currencies
+func (s *CollectionService) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*Balance, error) {
+	var result Balance
+	err := s.get(ctx, fmt.Sprintf("/collection/v1_0/account/balance/%s", strings.ToUpper(currency)), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account balance in %s: %w", currency, err)
+	}
+
+	return &result, nil
+}
+
+// GetBalances gets the balance of the account in every configured currency.
+// Balances that could be fetched are returned even if others failed.
+func (s *CollectionService) GetBalances(ctx context.Context) (map[string]*Balance, error) {
+	return getBalances(ctx, s.config.balanceCurrencies(), s.GetAccountBalanceInCurrency)
+}
+
+// IsAccountHolderActive checks that an account holder exists and is active.
+// The account holder can be identified by MSISDN, email or party code.
+func (s *CollectionService) IsAccountHolderActive(ctx context.Context, accountHolder PartyInfo) (bool, error) {
+	party, err := s.config.normalizeParty(accountHolder)
+	if err != nil {
+		return false, err
 	}
 
-	return result.AvailableBalance, result.Currency, nil
+	var result struct {
+		Result bool `json:"result"`
+	}
+	err = s.get(ctx, accountHolderPath("collection", party, "active"), &result)
+	if err != nil {
+		return false, fmt.Errorf("error validating account holder: %w", err)
+	}
+
+	return result.Result, nil
 }
 
 // GetAccountHolderInfo gets information about an account holder
 func (s *CollectionService) GetAccountHolderInfo(ctx context.Context, phone string) (*AccountHolderInfo, error) {
-	// Format phone number if needed
-	phone = formatPhoneNumber(phone)
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get access token
 	token, err := s.authService.GetAccessToken(ctx, "collection")
@@ -182,7 +354,7 @@ func (s *CollectionService) GetAccountHolderInfo(ctx context.Context, phone stri
 	var result AccountHolderInfo
 	req := Request{
 		Method: http.MethodGet,
-		Path:   fmt.Sprintf("/collection/v1_0/accountholder/MSISDN/%s/basicuserinfo", phone),
+		Path:   fmt.Sprintf("/collection/v1_0/accountholder/MSISDN/%s/basicuserinfo", msisdn),
 		Headers: map[string]string{
 			"Authorization":             "Bearer " + token,
 			"X-Target-Environment":      s.config.TargetEnvironment,
@@ -198,33 +370,100 @@ func (s *CollectionService) GetAccountHolderInfo(ctx context.Context, phone stri
 	return &result, nil
 }
 
-// Helper to format phone numbers consistently
-func formatPhoneNumber(phone string) string {
-	// Remove all non-digit characters
-	digitsOnly := strings.Map(func(r rune) rune {
-		if r >= '0' && r <= '9' {
-			return r
+// Helper for default strings
+func defaultIfEmpty(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// floatMoney converts a float amount using the currency from the options or
+// the configured default
+func (s *CollectionService) floatMoney(amount float64, opts *RequestToPayOptions) (Money, error) {
+	currency := s.config.Currency
+	if opts != nil && opts.Currency != "" {
+		currency = opts.Currency
+	}
+	return MoneyFromFloat(amount, currency)
+}
+
+// amountInCurrency gives an amount without a currency the requested one, or
+// the configured default, and validates it. An amount whose currency differs
+// from the requested one is rejected.
+func (s *CollectionService) amountInCurrency(amount Money, currency string) (Money, error) {
+	if amount.Currency == "" {
+		amount.Currency = defaultIfEmpty(currency, s.config.Currency)
+	} else if currency != "" && currency != amount.Currency {
+		return Money{}, fmt.Errorf("%w: amount in %s but currency %s requested", ErrInvalidAmount, amount.Currency, currency)
+	}
+	if err := amount.validate(); err != nil {
+		return Money{}, err
+	}
+	return amount, nil
+}
+
+// post performs an authenticated POST against the collection API. Payment
+// requests pass a reference ID, which also selects the callback URL.
+func (s *CollectionService) post(ctx context.Context, path string, payload interface{}, referenceID, idempotencyKey string, kind CallbackKind, callbackOverride string, result interface{}) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	// Create headers
+	headers := map[string]string{
+		"Authorization":             "Bearer " + token,
+		"X-Target-Environment":      s.config.TargetEnvironment,
+		"Ocp-Apim-Subscription-Key": s.config.SubscriptionKey,
+	}
+
+	if referenceID != "" {
+		headers["X-Reference-Id"] = referenceID
+
+		// Add callback URL if provided or configured
+		callbackURL, err := s.config.callbackURL(kind, referenceID, callbackOverride)
+		if err != nil {
+			return err
 		}
-		return -1
-	}, phone)
+		if callbackURL != "" {
+			headers["X-Callback-Url"] = callbackURL
+		}
+	}
 
-	// You may want to add specific country code logic here
-	// This is a simple example that ensures the number has a country code
-	if len(digitsOnly) > 0 && digitsOnly[0] == '0' {
-		// Replace leading 0 with country code (e.g., 231 for Liberia)
-		digitsOnly = "231" + digitsOnly[1:]
-	} else if !strings.HasPrefix(digitsOnly, "231") {
-		// Add country code if missing
-		digitsOnly = "231" + digitsOnly
+	// Add idempotency key if provided
+	if idempotencyKey != "" {
+		headers["X-Idempotency-Key"] = idempotencyKey
 	}
 
-	return digitsOnly
+	req := Request{
+		Method:  http.MethodPost,
+		Path:    path,
+		Body:    payload,
+		Headers: headers,
+	}
+
+	return s.client.DoRequest(ctx, req, result)
 }
 
-// Helper for default strings
-func defaultIfEmpty(value, defaultValue string) string {
-	if value == "" {
-		return defaultValue
+// get performs an authenticated GET request against the collection API
+func (s *CollectionService) get(ctx context.Context, path string, result interface{}) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
 	}
-	return value
+
+	req := Request{
+		Method: http.MethodGet,
+		Path:   path,
+		Headers: map[string]string{
+			"Authorization":             "Bearer " + token,
+			"X-Target-Environment":      s.config.TargetEnvironment,
+			"Ocp-Apim-Subscription-Key": s.config.SubscriptionKey,
+		},
+	}
+
+	return s.client.DoRequest(ctx, req, result)
 }
@@ -0,0 +1,80 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAmountCurrency(t *testing.T) {
+	requests := []struct {
+		name string
+		path string
+		send func(c *CollectionService, amount Money, currency string) error
+	}{
+		{"RequestToWithdraw", "/collection/v1_0/requesttowithdraw", func(c *CollectionService, amount Money, currency string) error {
+			_, err := c.RequestToWithdraw(context.Background(), "46733123450", amount, &RequestToWithdrawOptions{Currency: currency})
+			return err
+		}},
+		{"RequestToPayMoney", "/collection/v1_0/requesttopay", func(c *CollectionService, amount Money, currency string) error {
+			_, err := c.RequestToPayMoney(context.Background(), "46733123450", amount, &RequestToPayOptions{Currency: currency})
+			return err
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		amount       Money
+		currency     string
+		wantAmount   string
+		wantCurrency string
+		wantErr      error
+	}{
+		{"amount currency", NewMoney(2000, "UGX"), "", "2000", "UGX", nil},
+		{"default currency", Money{Amount: 2000}, "", "20.00", "EUR", nil},
+		{"override currency", Money{Amount: 2000}, "UGX", "2000", "UGX", nil},
+		{"matching override", NewMoney(2000, "UGX"), "UGX", "2000", "UGX", nil},
+		{"conflicting override", NewMoney(2000, "EUR"), "UGX", "", "", ErrInvalidAmount},
+		{"invalid override", Money{Amount: 2000}, "ugx", "", "", ErrInvalidAmount},
+	}
+
+	for _, req := range requests {
+		for _, tt := range tests {
+			t.Run(req.name+"/"+tt.name, func(t *testing.T) {
+				var sent RequestToPayPayload
+				mux := http.NewServeMux()
+				mux.HandleFunc("POST /collection/token/", func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+				})
+				mux.HandleFunc("POST "+req.path, func(w http.ResponseWriter, r *http.Request) {
+					json.NewDecoder(r.Body).Decode(&sent)
+					w.WriteHeader(http.StatusAccepted)
+				})
+				srv := httptest.NewServer(mux)
+				defer srv.Close()
+
+				config := newTestConfig(t, srv.URL, WithCurrency("EUR"))
+				client := NewClient(config)
+				collection := NewCollectionService(client, config, NewAuthService(client, config))
+
+				err := req.send(collection, tt.amount, tt.currency)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("%s error = %v, want %v", req.name, err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("%s: %v", req.name, err)
+				}
+				if sent.Amount != tt.wantAmount || sent.Currency != tt.wantCurrency {
+					t.Errorf("sent %s %s, want %s %s", sent.Amount, sent.Currency, tt.wantAmount, tt.wantCurrency)
+				}
+			})
+		}
+	}
+}
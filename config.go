@@ -2,7 +2,15 @@ package gomomo
 
 import (
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sir-george2500/gomomo/phone"
 )
 
 // EnvironmentType represents the MTN MoMo environment (sandbox or production)
@@ -18,15 +26,31 @@ type Config struct {
 	// Common configuration
 	SubscriptionKey   string          // Primary subscription key for API access
 	DisbursementKey   string          // Key for disbursement operations (can be same as SubscriptionKey)
+	RemittanceKey     string          // Key for remittance operations (can be same as SubscriptionKey)
 	TargetEnvironment string          // Target environment (e.g., "sandbox", "prod", country code)
 	CallbackHost      string          // Host for callback URLs
+	CallbackPath      string          // Path template for default callback URLs, e.g. "/momo/callback/{kind}/{referenceId}"
 	APIUser           string          // API user ID (auto-generated in sandbox, provided in production)
 	APIKey            string          // API key for the user
 	Environment       EnvironmentType // Sandbox or Production
 	Currency          string          // Default currency (EUR for sandbox, varies by country in production)
+	Currencies        []string        // Currencies held by multi-currency wallets (defaults to Currency)
+	Country           string          // Country for phone numbers: ISO code or calling code (derived from TargetEnvironment if empty)
 
 	// Environment-specific hosts
-	Host string // API host URL
+	Host    string // API host URL
+	BaseURL string // Full base URL (scheme, host and optional path prefix), overrides Host
+
+	// HTTP behaviour
+	HTTPClient  *http.Client      // Custom HTTP client (a 30 second timeout client is used if nil)
+	Transport   http.RoundTripper // Custom transport for the HTTP client
+	RetryPolicy RetryPolicy       // Retry policy for failed requests
+
+	// Interceptors run around every HTTP attempt, first one outermost
+	Interceptors []Interceptor
+
+	// Logger for API calls (nothing is logged if nil)
+	Logger *slog.Logger
 }
 
 // NewConfig creates a new MTN MoMo configuration
@@ -35,6 +59,7 @@ func NewConfig(environment EnvironmentType, opts ...ConfigOption) (*Config, erro
 	config := &Config{
 		Environment: environment,
 		Currency:    "EUR", // Default for sandbox
+		RetryPolicy: DefaultRetryPolicy(),
 	}
 
 	// Set environment-specific defaults
@@ -76,6 +101,13 @@ func WithDisbursementKey(key string) ConfigOption {
 	}
 }
 
+// WithRemittanceKey sets the remittance key
+func WithRemittanceKey(key string) ConfigOption {
+	return func(c *Config) {
+		c.RemittanceKey = key
+	}
+}
+
 // WithTargetEnvironment sets the target environment
 func WithTargetEnvironment(env string) ConfigOption {
 	return func(c *Config) {
@@ -90,6 +122,15 @@ func WithCallbackHost(host string) ConfigOption {
 	}
 }
 
+// WithCallbackPath sets the path template used to build a default
+// X-Callback-Url from the callback host. The {kind} and {referenceId}
+// placeholders are replaced for every request.
+func WithCallbackPath(path string) ConfigOption {
+	return func(c *Config) {
+		c.CallbackPath = path
+	}
+}
+
 // WithAPIUser sets the API user ID (usually for production)
 func WithAPIUser(user string) ConfigOption {
 	return func(c *Config) {
@@ -111,6 +152,63 @@ func WithHost(host string) ConfigOption {
 	}
 }
 
+// WithCurrencies sets the currencies held by multi-currency wallets, used
+// when fetching balances in all currencies
+func WithCurrencies(currencies ...string) ConfigOption {
+	return func(c *Config) {
+		c.Currencies = currencies
+	}
+}
+
+// WithCountry sets the country used to normalize phone numbers, as an ISO
+// code ("UG") or calling code ("256"). By default it is derived from the
+// target environment, e.g. "mtnuganda".
+func WithCountry(country string) ConfigOption {
+	return func(c *Config) {
+		c.Country = country
+	}
+}
+
+// WithBaseURL sets the full base URL of the API, e.g. "http://127.0.0.1:8080"
+// or "https://gateway.internal/momo". It takes precedence over the host.
+func WithBaseURL(baseURL string) ConfigOption {
+	return func(c *Config) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for API requests
+func WithHTTPClient(client *http.Client) ConfigOption {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport sets the transport used by the HTTP client, e.g. for proxies,
+// custom TLS roots or connection pool tuning
+func WithTransport(transport http.RoundTripper) ConfigOption {
+	return func(c *Config) {
+		c.Transport = transport
+	}
+}
+
+// WithInterceptors appends interceptors to the request chain. Interceptors
+// run in the order they are added, the first one being the outermost.
+func WithInterceptors(interceptors ...Interceptor) ConfigOption {
+	return func(c *Config) {
+		c.Interceptors = append(c.Interceptors, interceptors...)
+	}
+}
+
+// WithLogger sets the logger used for API calls. Credentials and tokens are
+// always redacted and MSISDNs masked; request and response bodies are only
+// logged at debug level.
+func WithLogger(logger *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
 // WithCurrency sets the default currency
 func WithCurrency(currency string) ConfigOption {
 	return func(c *Config) {
@@ -118,6 +216,13 @@ func WithCurrency(currency string) ConfigOption {
 	}
 }
 
+// WithRetryPolicy sets the retry policy for failed requests
+func WithRetryPolicy(policy RetryPolicy) ConfigOption {
+	return func(c *Config) {
+		c.RetryPolicy = policy
+	}
+}
+
 // FromEnv loads configuration from environment variables
 func FromEnv() ConfigOption {
 	return func(c *Config) {
@@ -127,15 +232,24 @@ func FromEnv() ConfigOption {
 		if key := os.Getenv("MOMO_DISBURSEMENT_KEY"); key != "" {
 			c.DisbursementKey = key
 		}
+		if key := os.Getenv("MOMO_REMITTANCE_KEY"); key != "" {
+			c.RemittanceKey = key
+		}
 		if env := os.Getenv("MOMO_TARGET_ENVIRONMENT"); env != "" {
 			c.TargetEnvironment = env
 		}
 		if host := os.Getenv("MOMO_CALLBACK_HOST"); host != "" {
 			c.CallbackHost = host
 		}
+		if path := os.Getenv("MOMO_CALLBACK_PATH"); path != "" {
+			c.CallbackPath = path
+		}
 		if host := os.Getenv("MOMO_HOST"); host != "" {
 			c.Host = host
 		}
+		if baseURL := os.Getenv("MOMO_BASE_URL"); baseURL != "" {
+			c.BaseURL = baseURL
+		}
 		if apiUser := os.Getenv("MOMO_API_USER"); apiUser != "" {
 			c.APIUser = apiUser
 		}
@@ -145,6 +259,12 @@ func FromEnv() ConfigOption {
 		if currency := os.Getenv("MOMO_CURRENCY"); currency != "" {
 			c.Currency = currency
 		}
+		if currencies := os.Getenv("MOMO_CURRENCIES"); currencies != "" {
+			c.Currencies = strings.Split(currencies, ",")
+		}
+		if country := os.Getenv("MOMO_COUNTRY"); country != "" {
+			c.Country = country
+		}
 	}
 }
 
@@ -156,7 +276,12 @@ func (c *Config) Validate() error {
 	if c.TargetEnvironment == "" {
 		return fmt.Errorf("target environment is required")
 	}
-	if c.Host == "" {
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("base URL must be an absolute http or https URL: %q", c.BaseURL)
+		}
+	} else if c.Host == "" {
 		return fmt.Errorf("host is required")
 	}
 	if c.Environment == Production && c.APIUser == "" && c.APIKey == "" {
@@ -166,8 +291,101 @@ func (c *Config) Validate() error {
 		// Use subscription key as default for disbursement if not specified
 		c.DisbursementKey = c.SubscriptionKey
 	}
+	if c.RemittanceKey == "" {
+		// Use subscription key as default for remittance if not specified
+		c.RemittanceKey = c.SubscriptionKey
+	}
 	if c.Currency == "" {
 		return fmt.Errorf("currency is required")
 	}
+	if c.Country != "" {
+		if _, ok := phone.Lookup(c.Country); !ok {
+			return fmt.Errorf("unknown country: %s", c.Country)
+		}
+	}
+	if c.CallbackPath != "" && c.CallbackHost == "" {
+		return fmt.Errorf("callback host is required when a callback path is set")
+	}
 	return nil
 }
+
+// APIBaseURL returns the base URL requests are sent to, without a trailing slash
+func (c *Config) APIBaseURL() string {
+	if c.BaseURL != "" {
+		return strings.TrimRight(c.BaseURL, "/")
+	}
+	return "https://" + c.Host
+}
+
+// PhoneCountry returns the numbering plan used for phone numbers, from the
+// configured country or the target environment. It reports false if neither
+// identifies a known country, as in the sandbox.
+func (c *Config) PhoneCountry() (phone.Country, bool) {
+	if c.Country != "" {
+		return phone.Lookup(c.Country)
+	}
+	return phone.Lookup(c.TargetEnvironment)
+}
+
+// ParsePhone validates and normalizes a phone number for the configured
+// country. Numbers are only checked for length if the country is unknown.
+func (c *Config) ParsePhone(number string) (phone.Number, error) {
+	country, _ := c.PhoneCountry()
+	return phone.Parse(number, country)
+}
+
+// normalizeMSISDN returns a phone number in the form expected by the API
+func (c *Config) normalizeMSISDN(number string) (string, error) {
+	parsed, err := c.ParsePhone(number)
+	if err != nil {
+		return "", err
+	}
+	return parsed.MSISDN(), nil
+}
+
+// Party codes are short identifiers made of letters, digits, '-', '_' and '.'
+var partyCodePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// normalizeParty validates and normalizes the ID of a party for its type:
+// MSISDNs are normalized for the configured country, while emails and party
+// codes are checked for syntax
+func (c *Config) normalizeParty(party PartyInfo) (PartyInfo, error) {
+	switch party.PartyIDType {
+	case MSISDN:
+		msisdn, err := c.normalizeMSISDN(party.PartyID)
+		if err != nil {
+			return PartyInfo{}, err
+		}
+		party.PartyID = msisdn
+	case Email:
+		address, err := mail.ParseAddress(strings.TrimSpace(party.PartyID))
+		if err != nil || address.Name != "" {
+			return PartyInfo{}, fmt.Errorf("%w: %q is not an email address", ErrInvalidPartyID, party.PartyID)
+		}
+		party.PartyID = address.Address
+	case Party:
+		code := strings.TrimSpace(party.PartyID)
+		if !partyCodePattern.MatchString(code) {
+			return PartyInfo{}, fmt.Errorf("%w: %q is not a party code", ErrInvalidPartyID, party.PartyID)
+		}
+		party.PartyID = code
+	default:
+		return PartyInfo{}, fmt.Errorf("%w: unsupported party ID type %q", ErrInvalidPartyID, party.PartyIDType)
+	}
+	return party, nil
+}
+
+// balanceCurrencies returns the currencies to fetch balances in
+func (c *Config) balanceCurrencies() []string {
+	if len(c.Currencies) == 0 {
+		return []string{c.Currency}
+	}
+
+	currencies := make([]string, 0, len(c.Currencies))
+	for _, currency := range c.Currencies {
+		if currency = strings.ToUpper(strings.TrimSpace(currency)); currency != "" {
+			currencies = append(currencies, currency)
+		}
+	}
+	return currencies
+}
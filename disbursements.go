@@ -2,8 +2,12 @@ package gomomo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 )
@@ -12,15 +16,46 @@ import (
 type DisbursementService struct {
 	client      *Client
 	config      *Config
-	authService *AuthService
+	authService TokenProvider
+
+	// collection looks up the request-to-pay a refund points to
+	collection TransactionStatusChecker
+
+	refundMu    sync.Mutex
+	refunds     map[string]*refundLedger // Refunds sent, by refunded reference ID
+	refundOrder []string                 // Refunded reference IDs, oldest first
+}
+
+// maxRefundLedgers bounds how many refunded collections are remembered; the
+// oldest ones not being refunded right now are forgotten first
+const maxRefundLedgers = 10000
+
+// refundLedger tracks the refunds sent for one request-to-pay. Its mutex is
+// held while a refund of that request-to-pay is checked and sent, so refunds
+// of different collections do not wait for each other.
+type refundLedger struct {
+	mu      sync.Mutex
+	users   int            // Refunds holding or waiting for mu, guarded by refundMu
+	settled int64          // Amount of refunds that succeeded
+	pending []refundRecord // Refunds without a final status yet
+}
+
+// refundRecord is a refund sent by this service
+type refundRecord struct {
+	referenceID string
+	amount      Money
 }
 
-// NewDisbursementService creates a new disbursement service
-func NewDisbursementService(client *Client, config *Config, authService *AuthService) *DisbursementService {
+// NewDisbursementService creates a new disbursement service. Refunds are
+// checked against a collection service sharing the same client; NewMoMoClient
+// wires in its own collection service instead.
+func NewDisbursementService(client *Client, config *Config, authService TokenProvider) *DisbursementService {
 	return &DisbursementService{
 		client:      client,
 		config:      config,
 		authService: authService,
+		collection:  NewCollectionService(client, config, authService),
+		refunds:     make(map[string]*refundLedger),
 	}
 }
 
@@ -29,15 +64,68 @@ type TransferOptions struct {
 	IdempotencyKey string // Custom idempotency key (generated if empty)
 	ExternalID     string // Custom external ID (generated if empty)
 	ReferenceID    string // Custom reference ID (generated if empty)
-	Currency       string // Override default currency
+	Currency       string // Override default currency (float amounts only)
 	PayerMessage   string // Message from the payer
 	PayeeNote      string // Note to the payee
+	CallbackURL    string // Callback URL (built from the config's callback path if empty)
+}
+
+// DepositOptions contains optional parameters for deposits
+type DepositOptions struct {
+	IdempotencyKey string     // Custom idempotency key (generated if empty)
+	ExternalID     string     // Custom external ID (generated if empty)
+	ReferenceID    string     // Custom reference ID (generated if empty)
+	PayerMessage   string     // Message from the payer
+	PayeeNote      string     // Note to the payee
+	CallbackURL    string     // Callback URL (built from the config's callback path if empty)
+	Version        APIVersion // Endpoint version (V1 if empty)
+}
+
+// RefundOptions contains optional parameters for refunds
+type RefundOptions struct {
+	IdempotencyKey string     // Custom idempotency key (generated if empty)
+	ExternalID     string     // Custom external ID (generated if empty)
+	ReferenceID    string     // Custom reference ID (generated if empty)
+	PayerMessage   string     // Message from the payer
+	PayeeNote      string     // Note to the payee
+	CallbackURL    string     // Callback URL (built from the config's callback path if empty)
+	Version        APIVersion // Endpoint version (V1 if empty)
+
+	// SkipCollectionCheck disables checking that the refunded request-to-pay
+	// exists, was successful and covers the refunded amount
+	SkipCollectionCheck bool
 }
 
 // Transfer initiates a transfer to a mobile money account
+//
+// Deprecated: float amounts are rounded to the currency's minor unit. Use
+// TransferMoney instead.
 func (s *DisbursementService) Transfer(ctx context.Context, phone string, amount float64, opts *TransferOptions) (string, error) {
-	// Format phone number if needed
-	phone = formatPhoneNumber(phone)
+	money, err := s.floatMoney(amount, opts)
+	if err != nil {
+		return "", err
+	}
+	return s.TransferMoney(ctx, phone, money, opts)
+}
+
+// TransferMoney initiates a transfer of an exact amount to a mobile money
+// account. The currency of the amount takes precedence over opts.Currency.
+func (s *DisbursementService) TransferMoney(ctx context.Context, phone string, amount Money, opts *TransferOptions) (string, error) {
+	return s.TransferToParty(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: phone}, amount, opts)
+}
+
+// TransferToParty initiates a transfer of an exact amount to a payee
+// identified by MSISDN, email or party code
+func (s *DisbursementService) TransferToParty(ctx context.Context, payee PartyInfo, amount Money, opts *TransferOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+
+	// Validate and normalize the payee
+	payee, err := s.config.normalizeParty(payee)
+	if err != nil {
+		return "", err
+	}
 
 	// Get access token
 	token, err := s.authService.GetAccessToken(ctx, "disbursement")
@@ -62,21 +150,12 @@ func (s *DisbursementService) Transfer(ctx context.Context, phone string, amount
 		externalID = uuid.New().String()
 	}
 
-	// Use provided currency or default
-	currency := opts.Currency
-	if currency == "" {
-		currency = s.config.Currency
-	}
-
 	// Create request payload
 	payload := TransferPayload{
-		Amount:     fmt.Sprintf("%.2f", amount),
-		Currency:   currency,
-		ExternalID: externalID,
-		Payee: PartyInfo{
-			PartyIDType: MSISDN,
-			PartyID:     phone,
-		},
+		Amount:       amount.AmountString(),
+		Currency:     amount.Currency,
+		ExternalID:   externalID,
+		Payee:        payee,
 		PayerMessage: defaultIfEmpty(opts.PayerMessage, "Disbursement payment"),
 		PayeeNote:    defaultIfEmpty(opts.PayeeNote, "Funds received"),
 	}
@@ -94,6 +173,15 @@ func (s *DisbursementService) Transfer(ctx context.Context, phone string, amount
 		headers["X-Idempotency-Key"] = opts.IdempotencyKey
 	}
 
+	// Add callback URL if provided or configured
+	callbackURL, err := s.config.callbackURL(CallbackTransfer, referenceID, opts.CallbackURL)
+	if err != nil {
+		return "", err
+	}
+	if callbackURL != "" {
+		headers["X-Callback-Url"] = callbackURL
+	}
+
 	// Make the request
 	req := Request{
 		Method:  http.MethodPost,
@@ -137,19 +225,296 @@ func (s *DisbursementService) GetTransferStatus(ctx context.Context, referenceID
 	return &result, nil
 }
 
+// WaitForFinalStatus polls the status of a transfer until it is no longer
+// pending or the context expires. If the transfer failed, was rejected or
+// timed out, the final status is returned along with a *TransactionError.
+func (s *DisbursementService) WaitForFinalStatus(ctx context.Context, referenceID string, opts *WaitOptions) (*TransactionStatusResponse, error) {
+	return waitForFinalStatus(ctx, referenceID, opts, s.GetTransferStatus)
+}
+
+// Deposit initiates a deposit of an exact amount into a mobile money account
+func (s *DisbursementService) Deposit(ctx context.Context, phone string, amount Money, opts *DepositOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return "", err
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &DepositOptions{}
+	}
+
+	path, err := versionedPath("/disbursement/%s/deposit", opts.Version)
+	if err != nil {
+		return "", err
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	// Create request payload
+	payload := TransferPayload{
+		Amount:     amount.AmountString(),
+		Currency:   amount.Currency,
+		ExternalID: externalID,
+		Payee: PartyInfo{
+			PartyIDType: MSISDN,
+			PartyID:     msisdn,
+		},
+		PayerMessage: defaultIfEmpty(opts.PayerMessage, "Deposit"),
+		PayeeNote:    defaultIfEmpty(opts.PayeeNote, "Funds received"),
+	}
+
+	err = s.post(ctx, path, payload, referenceID, opts.IdempotencyKey, CallbackDeposit, opts.CallbackURL)
+	if err != nil {
+		return "", fmt.Errorf("error making deposit: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetDepositStatus checks the status of a deposit
+func (s *DisbursementService) GetDepositStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	var result TransactionStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/disbursement/v1_0/deposit/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking deposit status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Refund refunds part or all of a successful request-to-pay to the payer.
+// Unless opts.SkipCollectionCheck is set, the request-to-pay is looked up
+// first and the refund is rejected with ErrInvalidRefund if it was not
+// successful or the amount, together with earlier refunds of it, exceeds
+// what was collected. Only refunds sent through this service count towards
+// that limit, and those that failed are not counted. Refunds of the same
+// request-to-pay are sent one at a time.
+func (s *DisbursementService) Refund(ctx context.Context, referenceIDToRefund string, amount Money, opts *RefundOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+	if referenceIDToRefund == "" {
+		return "", fmt.Errorf("%w: reference ID to refund is required", ErrInvalidRefund)
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &RefundOptions{}
+	}
+
+	path, err := versionedPath("/disbursement/%s/refund", opts.Version)
+	if err != nil {
+		return "", err
+	}
+
+	// Serialise refunds of the same collection so concurrent ones cannot
+	// together exceed the collected amount
+	ledger := s.lockRefunds(referenceIDToRefund)
+	defer s.unlockRefunds(referenceIDToRefund, ledger)
+
+	if !opts.SkipCollectionCheck {
+		if err := s.checkRefundable(ctx, referenceIDToRefund, ledger, amount); err != nil {
+			return "", err
+		}
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	// Create request payload
+	payload := RefundPayload{
+		Amount:              amount.AmountString(),
+		Currency:            amount.Currency,
+		ExternalID:          externalID,
+		PayerMessage:        defaultIfEmpty(opts.PayerMessage, "Refund"),
+		PayeeNote:           defaultIfEmpty(opts.PayeeNote, "Refund processed"),
+		ReferenceIDToRefund: referenceIDToRefund,
+	}
+
+	err = s.post(ctx, path, payload, referenceID, opts.IdempotencyKey, CallbackRefund, opts.CallbackURL)
+	if err != nil {
+		return "", fmt.Errorf("error making refund: %w", err)
+	}
+
+	ledger.pending = append(ledger.pending, refundRecord{
+		referenceID: referenceID,
+		amount:      amount,
+	})
+
+	return referenceID, nil
+}
+
+// GetRefundStatus checks the status of a refund
+func (s *DisbursementService) GetRefundStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	var result TransactionStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/disbursement/v1_0/refund/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking refund status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// checkRefundable verifies that a request-to-pay was successful and covers
+// the amount to refund on top of the refunds already sent for it
+func (s *DisbursementService) checkRefundable(ctx context.Context, referenceIDToRefund string, ledger *refundLedger, amount Money) error {
+	status, err := s.collection.GetTransactionStatus(ctx, referenceIDToRefund)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: no collection with reference ID %s", ErrInvalidRefund, referenceIDToRefund)
+	}
+	if err != nil {
+		return fmt.Errorf("error checking collection to refund: %w", err)
+	}
+
+	if status.Status != Successful {
+		return fmt.Errorf("%w: collection %s is %s", ErrInvalidRefund, referenceIDToRefund, status.Status)
+	}
+
+	collected, err := status.Money()
+	if err != nil {
+		return fmt.Errorf("error reading collected amount: %w", err)
+	}
+	if collected.Currency != amount.Currency {
+		return fmt.Errorf("%w: collection %s was made in %s, not %s", ErrInvalidRefund, referenceIDToRefund, collected.Currency, amount.Currency)
+	}
+	if amount.Amount > collected.Amount {
+		return fmt.Errorf("%w: refund of %s exceeds collected %s", ErrInvalidRefund, amount, collected)
+	}
+
+	refunded, err := s.refundedAmount(ctx, ledger)
+	if err != nil {
+		return err
+	}
+	if refunded+amount.Amount > collected.Amount {
+		return fmt.Errorf("%w: refund of %s exceeds the %s left of collected %s", ErrInvalidRefund,
+			amount, Money{Amount: collected.Amount - refunded, Currency: collected.Currency}, collected)
+	}
+
+	return nil
+}
+
+// refundedAmount sums the refunds sent for a request-to-pay that have not
+// failed. Refunds that are still pending count, since they may yet succeed.
+// Refunds with a final status are dropped from the ledger, successful ones
+// being added to its settled amount.
+func (s *DisbursementService) refundedAmount(ctx context.Context, ledger *refundLedger) (int64, error) {
+	settled := ledger.settled
+	var pending []refundRecord
+	var pendingAmount int64
+	for _, refund := range ledger.pending {
+		status, err := s.GetRefundStatus(ctx, refund.referenceID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return 0, fmt.Errorf("error checking earlier refund %s: %w", refund.referenceID, err)
+		}
+		if err == nil {
+			switch status.Status {
+			case Successful:
+				settled += refund.amount.Amount
+				continue
+			case Failed, Rejected, Timeout:
+				continue
+			}
+		}
+		pending = append(pending, refund)
+		pendingAmount += refund.amount.Amount
+	}
+
+	ledger.settled = settled
+	ledger.pending = pending
+	return settled + pendingAmount, nil
+}
+
+// lockRefunds locks the ledger of a refunded request-to-pay, creating it if
+// needed
+func (s *DisbursementService) lockRefunds(referenceIDToRefund string) *refundLedger {
+	s.refundMu.Lock()
+	ledger, ok := s.refunds[referenceIDToRefund]
+	if !ok {
+		ledger = &refundLedger{}
+		s.refunds[referenceIDToRefund] = ledger
+		s.refundOrder = append(s.refundOrder, referenceIDToRefund)
+	}
+	ledger.users++
+	s.evictRefundLedgers()
+	s.refundMu.Unlock()
+
+	ledger.mu.Lock()
+	return ledger
+}
+
+// unlockRefunds releases a ledger locked by lockRefunds, forgetting it if no
+// refund of the request-to-pay was ever sent
+func (s *DisbursementService) unlockRefunds(referenceIDToRefund string, ledger *refundLedger) {
+	empty := ledger.settled == 0 && len(ledger.pending) == 0
+	ledger.mu.Unlock()
+
+	s.refundMu.Lock()
+	defer s.refundMu.Unlock()
+	ledger.users--
+	if empty && ledger.users == 0 && s.refunds[referenceIDToRefund] == ledger {
+		delete(s.refunds, referenceIDToRefund)
+		s.refundOrder = slices.DeleteFunc(s.refundOrder, func(id string) bool { return id == referenceIDToRefund })
+	}
+}
+
+// evictRefundLedgers forgets the oldest idle ledgers while more than
+// maxRefundLedgers are remembered. The caller must hold refundMu.
+func (s *DisbursementService) evictRefundLedgers() {
+	for i := 0; len(s.refunds) > maxRefundLedgers && i < len(s.refundOrder); {
+		referenceID := s.refundOrder[i]
+		if s.refunds[referenceID].users > 0 {
+			i++
+			continue
+		}
+		delete(s.refunds, referenceID)
+		s.refundOrder = slices.Delete(s.refundOrder, i, i+1)
+	}
+}
+
 // GetAccountBalance gets the balance of the account
 func (s *DisbursementService) GetAccountBalance(ctx context.Context) (string, string, error) {
-	// Get access token
-	token, err := s.authService.GetAccessToken(ctx, "disbursement")
+	balance, err := s.GetBalance(ctx)
 	if err != nil {
-		return "", "", fmt.Errorf("error getting access token: %w", err)
+		return "", "", err
 	}
 
-	var result struct {
-		AvailableBalance string `json:"availableBalance"`
-		Currency         string `json:"currency"`
+	return balance.AvailableBalance, balance.Currency, nil
+}
+
+// GetBalance gets the balance of the account
+func (s *DisbursementService) GetBalance(ctx context.Context) (*Balance, error) {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "disbursement")
+	if err != nil {
+		return nil, fmt.Errorf("error getting access token: %w", err)
 	}
 
+	var result Balance
 	req := Request{
 		Method: http.MethodGet,
 		Path:   "/disbursement/v1_0/account/balance",
@@ -162,16 +527,56 @@ func (s *DisbursementService) GetAccountBalance(ctx context.Context) (string, st
 
 	err = s.client.DoRequest(ctx, req, &result)
 	if err != nil {
-		return "", "", fmt.Errorf("error getting account balance: %w", err)
+		return nil, fmt.Errorf("error getting account balance: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetAccountBalanceInCurrency gets the balance of the account in a specific
+// currency, for wallets holding several currencies
+func (s *DisbursementService) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*Balance, error) {
+	var result Balance
+	err := s.get(ctx, fmt.Sprintf("/disbursement/v1_0/account/balance/%s", strings.ToUpper(currency)), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account balance in %s: %w", currency, err)
 	}
 
-	return result.AvailableBalance, result.Currency, nil
+	return &result, nil
+}
+
+// GetBalances gets the balance of the account in every configured currency.
+// Balances that could be fetched are returned even if others failed.
+func (s *DisbursementService) GetBalances(ctx context.Context) (map[string]*Balance, error) {
+	return getBalances(ctx, s.config.balanceCurrencies(), s.GetAccountBalanceInCurrency)
+}
+
+// IsAccountHolderActive checks that an account holder exists and is active.
+// The account holder can be identified by MSISDN, email or party code.
+func (s *DisbursementService) IsAccountHolderActive(ctx context.Context, accountHolder PartyInfo) (bool, error) {
+	party, err := s.config.normalizeParty(accountHolder)
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Result bool `json:"result"`
+	}
+	err = s.get(ctx, accountHolderPath("disbursement", party, "active"), &result)
+	if err != nil {
+		return false, fmt.Errorf("error validating account holder: %w", err)
+	}
+
+	return result.Result, nil
 }
 
 // GetAccountHolderInfo gets information about an account holder
 func (s *DisbursementService) GetAccountHolderInfo(ctx context.Context, phone string) (*AccountHolderInfo, error) {
-	// Format phone number if needed
-	phone = formatPhoneNumber(phone)
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get access token
 	token, err := s.authService.GetAccessToken(ctx, "disbursement")
@@ -182,7 +587,7 @@ func (s *DisbursementService) GetAccountHolderInfo(ctx context.Context, phone st
 	var result AccountHolderInfo
 	req := Request{
 		Method: http.MethodGet,
-		Path:   fmt.Sprintf("/disbursement/v1_0/accountholder/MSISDN/%s/basicuserinfo", phone),
+		Path:   fmt.Sprintf("/disbursement/v1_0/accountholder/MSISDN/%s/basicuserinfo", msisdn),
 		Headers: map[string]string{
 			"Authorization":             "Bearer " + token,
 			"X-Target-Environment":      s.config.TargetEnvironment,
@@ -197,3 +602,74 @@ func (s *DisbursementService) GetAccountHolderInfo(ctx context.Context, phone st
 
 	return &result, nil
 }
+
+// floatMoney converts a float amount using the currency from the options or
+// the configured default
+func (s *DisbursementService) floatMoney(amount float64, opts *TransferOptions) (Money, error) {
+	currency := s.config.Currency
+	if opts != nil && opts.Currency != "" {
+		currency = opts.Currency
+	}
+	return MoneyFromFloat(amount, currency)
+}
+
+// post performs an authenticated payment POST against the disbursement API
+func (s *DisbursementService) post(ctx context.Context, path string, payload interface{}, referenceID, idempotencyKey string, kind CallbackKind, callbackOverride string) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "disbursement")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	// Create headers
+	headers := map[string]string{
+		"Authorization":             "Bearer " + token,
+		"X-Reference-Id":            referenceID,
+		"X-Target-Environment":      s.config.TargetEnvironment,
+		"Ocp-Apim-Subscription-Key": s.config.DisbursementKey,
+	}
+
+	// Add idempotency key if provided
+	if idempotencyKey != "" {
+		headers["X-Idempotency-Key"] = idempotencyKey
+	}
+
+	// Add callback URL if provided or configured
+	callbackURL, err := s.config.callbackURL(kind, referenceID, callbackOverride)
+	if err != nil {
+		return err
+	}
+	if callbackURL != "" {
+		headers["X-Callback-Url"] = callbackURL
+	}
+
+	req := Request{
+		Method:  http.MethodPost,
+		Path:    path,
+		Body:    payload,
+		Headers: headers,
+	}
+
+	return s.client.DoRequest(ctx, req, nil)
+}
+
+// get performs an authenticated GET request against the disbursement API
+func (s *DisbursementService) get(ctx context.Context, path string, result interface{}) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "disbursement")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	req := Request{
+		Method: http.MethodGet,
+		Path:   path,
+		Headers: map[string]string{
+			"Authorization":             "Bearer " + token,
+			"X-Target-Environment":      s.config.TargetEnvironment,
+			"Ocp-Apim-Subscription-Key": s.config.DisbursementKey,
+		},
+	}
+
+	return s.client.DoRequest(ctx, req, result)
+}
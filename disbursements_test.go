@@ -0,0 +1,261 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubCollection answers collection status lookups from a fixed response
+type stubCollection struct {
+	status *TransactionStatusResponse
+	err    error
+	calls  int
+}
+
+func (c *stubCollection) GetTransactionStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	c.calls++
+	return c.status, c.err
+}
+
+// refundServer accepts refunds and reports each with the next status in
+// statuses, or PENDING once they run out
+func refundServer(t *testing.T, statuses ...TransactionStatus) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	refunds := make(map[string]TransactionStatus)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /disbursement/token/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+	})
+	mux.HandleFunc("POST /disbursement/v1_0/refund", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		status := Pending
+		if len(refunds) < len(statuses) {
+			status = statuses[len(refunds)]
+		}
+		refunds[r.Header.Get("X-Reference-Id")] = status
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /disbursement/v1_0/refund/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		status, ok := refunds[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(TransactionStatusResponse{Status: status})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRefundCollectionCheck(t *testing.T) {
+	collected := &TransactionStatusResponse{Status: Successful, Amount: "10", Currency: "EUR"}
+
+	tests := []struct {
+		name       string
+		collection *stubCollection
+		earlier    []TransactionStatus // statuses of refunds sent before
+		amount     Money
+		wantErr    error
+	}{
+		{"full refund", &stubCollection{status: collected}, nil, NewMoney(1000, "EUR"), nil},
+		{"partial refund", &stubCollection{status: collected}, nil, NewMoney(400, "EUR"), nil},
+		{"exceeds collected", &stubCollection{status: collected}, nil, NewMoney(1001, "EUR"), ErrInvalidRefund},
+		{"wrong currency", &stubCollection{status: collected}, nil, NewMoney(100, "UGX"), ErrInvalidRefund},
+		{"unknown collection", &stubCollection{err: &MoMoError{StatusCode: http.StatusNotFound}}, nil, NewMoney(100, "EUR"), ErrInvalidRefund},
+		{"collection not successful", &stubCollection{status: &TransactionStatusResponse{Status: Failed}}, nil, NewMoney(100, "EUR"), ErrInvalidRefund},
+		{"lookup error", &stubCollection{err: &MoMoError{StatusCode: http.StatusInternalServerError}}, nil, NewMoney(100, "EUR"), ErrAPIRequestFailed},
+		{"earlier refunds leave room", &stubCollection{status: collected}, []TransactionStatus{Successful}, NewMoney(600, "EUR"), nil},
+		{"earlier successful refund", &stubCollection{status: collected}, []TransactionStatus{Successful}, NewMoney(601, "EUR"), ErrInvalidRefund},
+		{"earlier pending refund", &stubCollection{status: collected}, []TransactionStatus{Pending}, NewMoney(601, "EUR"), ErrInvalidRefund},
+		{"earlier failed refund", &stubCollection{status: collected}, []TransactionStatus{Failed}, NewMoney(1000, "EUR"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := refundServer(t, tt.earlier...)
+			config := newTestConfig(t, srv.URL)
+			client := NewClient(config)
+			disbursement := NewDisbursementService(client, config, NewAuthService(client, config))
+			disbursement.collection = tt.collection
+
+			ctx := context.Background()
+			for range tt.earlier {
+				if _, err := disbursement.Refund(ctx, "collection-1", NewMoney(400, "EUR"), &RefundOptions{SkipCollectionCheck: true}); err != nil {
+					t.Fatalf("earlier refund: %v", err)
+				}
+			}
+
+			_, err := disbursement.Refund(ctx, "collection-1", tt.amount, nil)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Refund: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Refund error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.collection.calls != 1 {
+				t.Errorf("collection looked up %d times, want 1", tt.collection.calls)
+			}
+		})
+	}
+}
+
+func TestRefundUsesClientCollectionService(t *testing.T) {
+	config := newTestConfig(t, "http://momo.invalid")
+	momo := NewMoMoClient(config)
+
+	disbursement := momo.Disbursement.(*DisbursementService)
+	if disbursement.collection != momo.Collection {
+		t.Error("refunds are not checked against the client's collection service")
+	}
+}
+
+func TestRefundSkipCollectionCheck(t *testing.T) {
+	srv := refundServer(t)
+	config := newTestConfig(t, srv.URL)
+	client := NewClient(config)
+	disbursement := NewDisbursementService(client, config, NewAuthService(client, config))
+	collection := &stubCollection{err: errors.New("unexpected lookup")}
+	disbursement.collection = collection
+
+	referenceID, err := disbursement.Refund(context.Background(), "collection-1", NewMoney(100, "EUR"), &RefundOptions{SkipCollectionCheck: true})
+	if err != nil {
+		t.Fatalf("Refund: %v", err)
+	}
+	if referenceID == "" {
+		t.Error("Refund returned an empty reference ID")
+	}
+	if collection.calls != 0 {
+		t.Errorf("collection looked up %d times, want 0", collection.calls)
+	}
+}
+
+// gatedCollection holds the lookup of collection-1 until collection-2 has
+// been looked up
+type gatedCollection struct {
+	collection2 chan struct{}
+}
+
+func (c *gatedCollection) GetTransactionStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	if referenceID == "collection-2" {
+		close(c.collection2)
+	} else {
+		select {
+		case <-c.collection2:
+		case <-time.After(time.Second):
+			return nil, errors.New("refund of collection-2 waited for collection-1")
+		}
+	}
+	return &TransactionStatusResponse{Status: Successful, Amount: "10", Currency: "EUR"}, nil
+}
+
+func TestRefundDifferentCollectionsConcurrently(t *testing.T) {
+	srv := refundServer(t)
+	config := newTestConfig(t, srv.URL)
+	client := NewClient(config)
+	disbursement := NewDisbursementService(client, config, NewAuthService(client, config))
+	disbursement.collection = &gatedCollection{collection2: make(chan struct{})}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, referenceID := range []string{"collection-1", "collection-2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = disbursement.Refund(context.Background(), referenceID, NewMoney(500, "EUR"), nil)
+		}()
+		if i == 0 {
+			// Let the first refund take its lock before the second starts
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("Refund: %v", err)
+		}
+	}
+}
+
+func TestRefundLedgerPrunesFinalRefunds(t *testing.T) {
+	srv := refundServer(t, Successful, Failed, Pending)
+	config := newTestConfig(t, srv.URL)
+	client := NewClient(config)
+	disbursement := NewDisbursementService(client, config, NewAuthService(client, config))
+	disbursement.collection = &stubCollection{status: &TransactionStatusResponse{Status: Successful, Amount: "10", Currency: "EUR"}}
+
+	ctx := context.Background()
+	for _, amount := range []int64{300, 300, 200, 100} {
+		if _, err := disbursement.Refund(ctx, "collection-1", NewMoney(amount, "EUR"), nil); err != nil {
+			t.Fatalf("Refund of %d: %v", amount, err)
+		}
+	}
+
+	ledger := disbursement.refunds["collection-1"]
+	if ledger.settled != 300 {
+		t.Errorf("settled = %d, want 300", ledger.settled)
+	}
+	// The failed refund is dropped, the pending one and the last refund kept
+	if len(ledger.pending) != 2 {
+		t.Errorf("pending refunds = %+v, want 2", ledger.pending)
+	}
+
+	// 300 settled and 300 pending leave 400 of the 1000 collected
+	if _, err := disbursement.Refund(ctx, "collection-1", NewMoney(401, "EUR"), nil); !errors.Is(err, ErrInvalidRefund) {
+		t.Errorf("Refund over the remaining amount: err = %v, want ErrInvalidRefund", err)
+	}
+}
+
+func TestRefundLedgerForgottenWhenNothingSent(t *testing.T) {
+	config := newTestConfig(t, "http://momo.invalid")
+	client := NewClient(config)
+	disbursement := NewDisbursementService(client, config, NewAuthService(client, config))
+	disbursement.collection = &stubCollection{status: &TransactionStatusResponse{Status: Failed}}
+
+	if _, err := disbursement.Refund(context.Background(), "collection-1", NewMoney(100, "EUR"), nil); !errors.Is(err, ErrInvalidRefund) {
+		t.Fatalf("err = %v, want ErrInvalidRefund", err)
+	}
+	if len(disbursement.refunds) != 0 || len(disbursement.refundOrder) != 0 {
+		t.Errorf("ledger kept for a collection that was never refunded")
+	}
+}
+
+func TestEvictRefundLedgers(t *testing.T) {
+	disbursement := &DisbursementService{refunds: make(map[string]*refundLedger)}
+	for i := range maxRefundLedgers + 2 {
+		referenceID := fmt.Sprintf("collection-%d", i)
+		disbursement.refunds[referenceID] = &refundLedger{settled: 100}
+		disbursement.refundOrder = append(disbursement.refundOrder, referenceID)
+	}
+	// The oldest ledger is being used by a refund
+	disbursement.refunds["collection-0"].users = 1
+
+	disbursement.evictRefundLedgers()
+
+	if len(disbursement.refunds) != maxRefundLedgers || len(disbursement.refundOrder) != maxRefundLedgers {
+		t.Fatalf("%d ledgers remembered in order %d, want %d", len(disbursement.refunds), len(disbursement.refundOrder), maxRefundLedgers)
+	}
+	for _, referenceID := range []string{"collection-1", "collection-2"} {
+		if _, ok := disbursement.refunds[referenceID]; ok {
+			t.Errorf("%s was not evicted", referenceID)
+		}
+	}
+	if _, ok := disbursement.refunds["collection-0"]; !ok {
+		t.Error("ledger in use was evicted")
+	}
+}
@@ -1,8 +1,12 @@
 package gomomo
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+
+	"github.com/sir-george2500/gomomo/phone"
 )
 
 // Pre-defined errors
@@ -12,6 +16,18 @@ var (
 	ErrAPIRequestFailed     = errors.New("API request failed")
 	ErrInvalidResponse      = errors.New("invalid response from API")
 	ErrTransactionFailed    = errors.New("transaction failed")
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrForbidden            = errors.New("forbidden")
+	ErrNotFound             = errors.New("resource not found")
+	ErrDuplicateReferenceID = errors.New("duplicate reference ID")
+	ErrRateLimited          = errors.New("rate limited")
+	ErrInvalidCallbackURL   = errors.New("invalid callback URL")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrInvalidPhoneNumber   = phone.ErrInvalidNumber
+	ErrInvalidRefund        = errors.New("invalid refund")
+	ErrInvalidPreApproval   = errors.New("invalid pre-approval")
+	ErrInvalidPartyID       = errors.New("invalid party ID")
+	ErrCassetteMismatch     = errors.New("no recorded interaction matches request")
 )
 
 // MoMoError represents a MTN MoMo API error
@@ -20,6 +36,8 @@ type MoMoError struct {
 	Message    string
 	StatusCode int
 	Details    map[string]interface{}
+	Body       string      // Raw response body
+	Header     http.Header // Response headers
 }
 
 // Error implements the error interface
@@ -27,6 +45,53 @@ func (e *MoMoError) Error() string {
 	return fmt.Sprintf("MTN MoMo API error: %s (%s), status: %d", e.Message, e.Code, e.StatusCode)
 }
 
+// Is reports whether the error matches one of the pre-defined errors, so that
+// callers can use errors.Is(err, ErrAuthenticationFailed) and friends
+func (e *MoMoError) Is(target error) bool {
+	return target == e.sentinel()
+}
+
+// sentinel maps the error to the matching pre-defined error
+func (e *MoMoError) sentinel() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrInvalidRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrAuthenticationFailed
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrDuplicateReferenceID
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return ErrAPIRequestFailed
+	}
+}
+
+// TransactionError is returned when a transaction reaches a final status
+// other than SUCCESSFUL
+type TransactionError struct {
+	ReferenceID string
+	Status      TransactionStatus
+	Reason      string
+}
+
+// Error implements the error interface
+func (e *TransactionError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("transaction %s %s", e.ReferenceID, e.Status)
+	}
+	return fmt.Sprintf("transaction %s %s: %s", e.ReferenceID, e.Status, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrTransactionFailed) match any TransactionError
+func (e *TransactionError) Is(target error) bool {
+	return target == ErrTransactionFailed
+}
+
 // NewMoMoError creates a new MoMo error
 func NewMoMoError(code, message string, statusCode int, details map[string]interface{}) *MoMoError {
 	return &MoMoError{
@@ -41,3 +106,37 @@ func NewMoMoError(code, message string, statusCode int, details map[string]inter
 func WrapError(err error, message string) error {
 	return fmt.Errorf("%s: %w", message, err)
 }
+
+// parseMoMoError builds a MoMoError from a non-2xx API response. MTN returns
+// {"code": ..., "message": ...} bodies, while the API gateway in front of it
+// uses {"statusCode": ..., "message": ...} for authentication failures.
+func parseMoMoError(statusCode int, header http.Header, body []byte) *MoMoError {
+	momoErr := &MoMoError{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Header:     header,
+	}
+
+	var details map[string]interface{}
+	if err := json.Unmarshal(body, &details); err == nil {
+		momoErr.Details = details
+		if code, ok := details["code"].(string); ok {
+			momoErr.Code = code
+		}
+		if message, ok := details["message"].(string); ok {
+			momoErr.Message = message
+		}
+	}
+
+	if momoErr.Code == "" {
+		momoErr.Code = http.StatusText(statusCode)
+	}
+	if momoErr.Message == "" {
+		momoErr.Message = string(body)
+	}
+	if momoErr.Message == "" {
+		momoErr.Message = http.StatusText(statusCode)
+	}
+
+	return momoErr
+}
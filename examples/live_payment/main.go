@@ -92,34 +92,26 @@ func main() {
 	log.Printf("Payment request initiated with reference ID: %s", referenceID)
 	log.Println("CHECK YOUR PHONE TO APPROVE THE PAYMENT")
 
-	// Poll for status with longer timeout and more attempts for production
-	log.Println("Polling for transaction status...")
-	maxPolls := 8
-	pollInterval := 10 * time.Second
+	// Wait for a final status, logging every poll
+	log.Println("Waiting for final transaction status...")
+	waitOpts := &gomomo.WaitOptions{
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     15 * time.Second,
+		OnPoll: func(attempt int, status *gomomo.TransactionStatusResponse, err error) {
+			if err != nil {
+				log.Printf("Polling attempt %d: error checking status: %v", attempt, err)
+				return
+			}
+			log.Printf("Polling attempt %d: status %s", attempt, status.Status)
+		},
+	}
 
 	var paymentSuccessful bool
-	for i := 0; i < maxPolls; i++ {
-		log.Printf("Polling attempt %d/%d...", i+1, maxPolls)
-		status, err := client.Collection.GetTransactionStatus(ctx, referenceID)
-		if err != nil {
-			log.Printf("Error checking status: %v", err)
-			time.Sleep(pollInterval)
-			continue
-		}
-
-		log.Printf("Transaction status: %s", status.Status)
-
-		// If we have a final status, break the loop
-		if status.Status == gomomo.Successful {
-			log.Printf("Payment successful!")
-			paymentSuccessful = true
-			break
-		} else if status.Status == gomomo.Failed || status.Status == gomomo.Rejected {
-			log.Printf("Payment failed with status: %s", status.Status)
-			break
-		}
-
-		time.Sleep(pollInterval)
+	if _, err := client.Collection.WaitForFinalStatus(ctx, referenceID, waitOpts); err != nil {
+		log.Printf("Payment did not succeed: %v", err)
+	} else {
+		log.Printf("Payment successful!")
+		paymentSuccessful = true
 	}
 
 	// Only proceed with disbursement if collection was successful
@@ -156,30 +148,12 @@ func main() {
 			}
 			log.Printf("Transfer initiated with reference ID: %s", transferReferenceID)
 
-			// Poll for transfer status
-			log.Println("Polling for transfer status...")
-			for i := 0; i < maxPolls; i++ {
-				log.Printf("Polling attempt %d/%d...", i+1, maxPolls)
-				status, err := client.Disbursement.GetTransferStatus(ctx, transferReferenceID)
-				if err != nil {
-					log.Printf("Error checking transfer status: %v", err)
-					time.Sleep(pollInterval)
-					continue
-				}
-
-				log.Printf("Transfer status: %s", status.Status)
-
-				// If we have a final status, break the loop
-				if status.Status == gomomo.Successful {
-					log.Printf("Transfer successful!")
-					break
-				} else if status.Status == gomomo.Failed ||
-					status.Status == gomomo.Rejected {
-					log.Printf("Transfer failed with status: %s", status.Status)
-					break
-				}
-
-				time.Sleep(pollInterval)
+			// Wait for the transfer to complete
+			log.Println("Waiting for final transfer status...")
+			if _, err := client.Disbursement.WaitForFinalStatus(ctx, transferReferenceID, waitOpts); err != nil {
+				log.Printf("Transfer did not succeed: %v", err)
+			} else {
+				log.Printf("Transfer successful!")
 			}
 		}
 	}
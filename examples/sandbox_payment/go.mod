@@ -1,6 +1,6 @@
 module github.com/sir-george2500/gomomo/examples/sandbox_payment
 
-go 1.23.5
+go 1.24.1
 
 replace github.com/sir-george2500/gomomo => ../../
 
@@ -34,8 +34,8 @@ func main() {
 
 	// Example phone number for sandbox testing
 	// In sandbox, use a number that will auto-respond to your request
-	phone := "46733123454" // This is an example - check MTN docs for valid test numbers
-	amount := 5.00
+	phone := "46733123454"                // This is an example - check MTN docs for valid test numbers
+	amount := gomomo.NewMoney(500, "EUR") // 5.00 EUR, in cents
 
 	// Create idempotency key for the request
 	idempotencyKey := gomomo.GenerateIdempotencyKey("test_payment", time.Now().Format("20060102150405"))
@@ -43,7 +43,7 @@ func main() {
 
 	// Initiate payment request
 	log.Println("Initiating payment request...")
-	referenceID, err := client.Collection.RequestToPay(
+	referenceID, err := client.Collection.RequestToPayMoney(
 		ctx,
 		phone,
 		amount,
@@ -89,10 +89,10 @@ func main() {
 	log.Println("\nTesting disbursement...")
 
 	disbursementIdempotencyKey := gomomo.GenerateIdempotencyKey("test_disbursement", time.Now().Format("20060102150405"))
-	transferReferenceID, err := client.Disbursement.Transfer(
+	transferReferenceID, err := client.Disbursement.TransferMoney(
 		ctx,
 		phone,
-		2.50,
+		gomomo.NewMoney(250, "EUR"),
 		&gomomo.TransferOptions{
 			IdempotencyKey: disbursementIdempotencyKey,
 			PayerMessage:   "Test disbursement",
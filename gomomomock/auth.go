@@ -0,0 +1,56 @@
+package gomomomock
+
+import (
+	"context"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Authenticator is a mock gomomo.Authenticator, which also satisfies
+// gomomo.TokenProvider. Each method records its call and delegates to the
+// matching Func field. Methods with results panic if it is not set.
+type Authenticator struct {
+	Recorder
+
+	GetAccessTokenFunc  func(ctx context.Context, product string) (string, error)
+	InvalidateTokenFunc func(product string)
+	CreateAPIUserFunc   func(ctx context.Context) (string, error)
+	CreateAPIKeyFunc    func(ctx context.Context, apiUserID string) (string, error)
+}
+
+var _ gomomo.Authenticator = (*Authenticator)(nil)
+
+// GetAccessToken records the call and calls GetAccessTokenFunc
+func (m *Authenticator) GetAccessToken(ctx context.Context, product string) (string, error) {
+	m.record("GetAccessToken", product)
+	if m.GetAccessTokenFunc == nil {
+		panic("gomomomock: Authenticator.GetAccessToken called but GetAccessTokenFunc is not set")
+	}
+	return m.GetAccessTokenFunc(ctx, product)
+}
+
+// InvalidateToken records the call and calls InvalidateTokenFunc, if set
+func (m *Authenticator) InvalidateToken(product string) {
+	m.record("InvalidateToken", product)
+	if m.InvalidateTokenFunc != nil {
+		m.InvalidateTokenFunc(product)
+	}
+}
+
+// CreateAPIUser records the call and calls CreateAPIUserFunc
+func (m *Authenticator) CreateAPIUser(ctx context.Context) (string, error) {
+	m.record("CreateAPIUser")
+	if m.CreateAPIUserFunc == nil {
+		panic("gomomomock: Authenticator.CreateAPIUser called but CreateAPIUserFunc is not set")
+	}
+	return m.CreateAPIUserFunc(ctx)
+}
+
+// CreateAPIKey records the call and calls CreateAPIKeyFunc
+func (m *Authenticator) CreateAPIKey(ctx context.Context, apiUserID string) (string, error) {
+	m.record("CreateAPIKey", apiUserID)
+	if m.CreateAPIKeyFunc == nil {
+		panic("gomomomock: Authenticator.CreateAPIKey called but CreateAPIKeyFunc is not set")
+	}
+	return m.CreateAPIKeyFunc(ctx, apiUserID)
+}
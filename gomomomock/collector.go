@@ -0,0 +1,253 @@
+package gomomomock
+
+import (
+	"context"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Collector is a mock gomomo.Collector. Each method records its call and delegates
+// to the matching Func field, panicking if it is not set.
+type Collector struct {
+	Recorder
+
+	// gomomo.PaymentRequester
+	RequestToPayFunc          func(ctx context.Context, phone string, amount float64, opts *gomomo.RequestToPayOptions) (string, error)
+	RequestToPayMoneyFunc     func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error)
+	RequestToPayFromPartyFunc func(ctx context.Context, payer gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error)
+
+	// gomomo.TransactionStatusChecker
+	GetTransactionStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.StatusWaiter
+	WaitForFinalStatusFunc func(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.WithdrawalRequester
+	RequestToWithdrawFunc func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToWithdrawOptions) (string, error)
+	GetWithdrawStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.PreApprovalManager
+	CreatePreApprovalFunc       func(ctx context.Context, phone string, opts *gomomo.PreApprovalOptions) (string, error)
+	GetPreApprovalStatusFunc    func(ctx context.Context, preApprovalID string) (*gomomo.PreApprovalStatusResponse, error)
+	GetApprovedPreApprovalsFunc func(ctx context.Context, accountHolder gomomo.PartyInfo) ([]gomomo.ApprovedPreApproval, error)
+	CancelPreApprovalFunc       func(ctx context.Context, preApprovalID string) error
+
+	// gomomo.InvoiceManager
+	CreateInvoiceFunc    func(ctx context.Context, intendedPayer gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.InvoiceOptions) (string, error)
+	GetInvoiceStatusFunc func(ctx context.Context, referenceID string) (*gomomo.InvoiceStatusResponse, error)
+	CancelInvoiceFunc    func(ctx context.Context, referenceID string, externalID string) error
+
+	// gomomo.BillPayer
+	CreatePaymentFunc    func(ctx context.Context, customerReference string, serviceProviderUserName string, amount gomomo.Money, opts *gomomo.PaymentOptions) (string, error)
+	GetPaymentStatusFunc func(ctx context.Context, referenceID string) (*gomomo.PaymentStatusResponse, error)
+
+	// gomomo.BalanceChecker
+	GetAccountBalanceFunc           func(ctx context.Context) (string, string, error)
+	GetBalanceFunc                  func(ctx context.Context) (*gomomo.Balance, error)
+	GetAccountBalanceInCurrencyFunc func(ctx context.Context, currency string) (*gomomo.Balance, error)
+	GetBalancesFunc                 func(ctx context.Context) (map[string]*gomomo.Balance, error)
+
+	// gomomo.AccountHolderChecker
+	IsAccountHolderActiveFunc func(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error)
+	GetAccountHolderInfoFunc  func(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error)
+}
+
+var _ gomomo.Collector = (*Collector)(nil)
+
+// RequestToPay records the call and calls RequestToPayFunc
+func (m *Collector) RequestToPay(ctx context.Context, phone string, amount float64, opts *gomomo.RequestToPayOptions) (string, error) {
+	m.record("RequestToPay", phone, amount, opts)
+	if m.RequestToPayFunc == nil {
+		panic("gomomomock: Collector.RequestToPay called but RequestToPayFunc is not set")
+	}
+	return m.RequestToPayFunc(ctx, phone, amount, opts)
+}
+
+// RequestToPayMoney records the call and calls RequestToPayMoneyFunc
+func (m *Collector) RequestToPayMoney(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error) {
+	m.record("RequestToPayMoney", phone, amount, opts)
+	if m.RequestToPayMoneyFunc == nil {
+		panic("gomomomock: Collector.RequestToPayMoney called but RequestToPayMoneyFunc is not set")
+	}
+	return m.RequestToPayMoneyFunc(ctx, phone, amount, opts)
+}
+
+// RequestToPayFromParty records the call and calls RequestToPayFromPartyFunc
+func (m *Collector) RequestToPayFromParty(ctx context.Context, payer gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error) {
+	m.record("RequestToPayFromParty", payer, amount, opts)
+	if m.RequestToPayFromPartyFunc == nil {
+		panic("gomomomock: Collector.RequestToPayFromParty called but RequestToPayFromPartyFunc is not set")
+	}
+	return m.RequestToPayFromPartyFunc(ctx, payer, amount, opts)
+}
+
+// GetTransactionStatus records the call and calls GetTransactionStatusFunc
+func (m *Collector) GetTransactionStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetTransactionStatus", referenceID)
+	if m.GetTransactionStatusFunc == nil {
+		panic("gomomomock: Collector.GetTransactionStatus called but GetTransactionStatusFunc is not set")
+	}
+	return m.GetTransactionStatusFunc(ctx, referenceID)
+}
+
+// WaitForFinalStatus records the call and calls WaitForFinalStatusFunc
+func (m *Collector) WaitForFinalStatus(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error) {
+	m.record("WaitForFinalStatus", referenceID, opts)
+	if m.WaitForFinalStatusFunc == nil {
+		panic("gomomomock: Collector.WaitForFinalStatus called but WaitForFinalStatusFunc is not set")
+	}
+	return m.WaitForFinalStatusFunc(ctx, referenceID, opts)
+}
+
+// RequestToWithdraw records the call and calls RequestToWithdrawFunc
+func (m *Collector) RequestToWithdraw(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToWithdrawOptions) (string, error) {
+	m.record("RequestToWithdraw", phone, amount, opts)
+	if m.RequestToWithdrawFunc == nil {
+		panic("gomomomock: Collector.RequestToWithdraw called but RequestToWithdrawFunc is not set")
+	}
+	return m.RequestToWithdrawFunc(ctx, phone, amount, opts)
+}
+
+// GetWithdrawStatus records the call and calls GetWithdrawStatusFunc
+func (m *Collector) GetWithdrawStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetWithdrawStatus", referenceID)
+	if m.GetWithdrawStatusFunc == nil {
+		panic("gomomomock: Collector.GetWithdrawStatus called but GetWithdrawStatusFunc is not set")
+	}
+	return m.GetWithdrawStatusFunc(ctx, referenceID)
+}
+
+// CreatePreApproval records the call and calls CreatePreApprovalFunc
+func (m *Collector) CreatePreApproval(ctx context.Context, phone string, opts *gomomo.PreApprovalOptions) (string, error) {
+	m.record("CreatePreApproval", phone, opts)
+	if m.CreatePreApprovalFunc == nil {
+		panic("gomomomock: Collector.CreatePreApproval called but CreatePreApprovalFunc is not set")
+	}
+	return m.CreatePreApprovalFunc(ctx, phone, opts)
+}
+
+// GetPreApprovalStatus records the call and calls GetPreApprovalStatusFunc
+func (m *Collector) GetPreApprovalStatus(ctx context.Context, preApprovalID string) (*gomomo.PreApprovalStatusResponse, error) {
+	m.record("GetPreApprovalStatus", preApprovalID)
+	if m.GetPreApprovalStatusFunc == nil {
+		panic("gomomomock: Collector.GetPreApprovalStatus called but GetPreApprovalStatusFunc is not set")
+	}
+	return m.GetPreApprovalStatusFunc(ctx, preApprovalID)
+}
+
+// GetApprovedPreApprovals records the call and calls GetApprovedPreApprovalsFunc
+func (m *Collector) GetApprovedPreApprovals(ctx context.Context, accountHolder gomomo.PartyInfo) ([]gomomo.ApprovedPreApproval, error) {
+	m.record("GetApprovedPreApprovals", accountHolder)
+	if m.GetApprovedPreApprovalsFunc == nil {
+		panic("gomomomock: Collector.GetApprovedPreApprovals called but GetApprovedPreApprovalsFunc is not set")
+	}
+	return m.GetApprovedPreApprovalsFunc(ctx, accountHolder)
+}
+
+// CancelPreApproval records the call and calls CancelPreApprovalFunc
+func (m *Collector) CancelPreApproval(ctx context.Context, preApprovalID string) error {
+	m.record("CancelPreApproval", preApprovalID)
+	if m.CancelPreApprovalFunc == nil {
+		panic("gomomomock: Collector.CancelPreApproval called but CancelPreApprovalFunc is not set")
+	}
+	return m.CancelPreApprovalFunc(ctx, preApprovalID)
+}
+
+// CreateInvoice records the call and calls CreateInvoiceFunc
+func (m *Collector) CreateInvoice(ctx context.Context, intendedPayer gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.InvoiceOptions) (string, error) {
+	m.record("CreateInvoice", intendedPayer, amount, opts)
+	if m.CreateInvoiceFunc == nil {
+		panic("gomomomock: Collector.CreateInvoice called but CreateInvoiceFunc is not set")
+	}
+	return m.CreateInvoiceFunc(ctx, intendedPayer, amount, opts)
+}
+
+// GetInvoiceStatus records the call and calls GetInvoiceStatusFunc
+func (m *Collector) GetInvoiceStatus(ctx context.Context, referenceID string) (*gomomo.InvoiceStatusResponse, error) {
+	m.record("GetInvoiceStatus", referenceID)
+	if m.GetInvoiceStatusFunc == nil {
+		panic("gomomomock: Collector.GetInvoiceStatus called but GetInvoiceStatusFunc is not set")
+	}
+	return m.GetInvoiceStatusFunc(ctx, referenceID)
+}
+
+// CancelInvoice records the call and calls CancelInvoiceFunc
+func (m *Collector) CancelInvoice(ctx context.Context, referenceID string, externalID string) error {
+	m.record("CancelInvoice", referenceID, externalID)
+	if m.CancelInvoiceFunc == nil {
+		panic("gomomomock: Collector.CancelInvoice called but CancelInvoiceFunc is not set")
+	}
+	return m.CancelInvoiceFunc(ctx, referenceID, externalID)
+}
+
+// CreatePayment records the call and calls CreatePaymentFunc
+func (m *Collector) CreatePayment(ctx context.Context, customerReference string, serviceProviderUserName string, amount gomomo.Money, opts *gomomo.PaymentOptions) (string, error) {
+	m.record("CreatePayment", customerReference, serviceProviderUserName, amount, opts)
+	if m.CreatePaymentFunc == nil {
+		panic("gomomomock: Collector.CreatePayment called but CreatePaymentFunc is not set")
+	}
+	return m.CreatePaymentFunc(ctx, customerReference, serviceProviderUserName, amount, opts)
+}
+
+// GetPaymentStatus records the call and calls GetPaymentStatusFunc
+func (m *Collector) GetPaymentStatus(ctx context.Context, referenceID string) (*gomomo.PaymentStatusResponse, error) {
+	m.record("GetPaymentStatus", referenceID)
+	if m.GetPaymentStatusFunc == nil {
+		panic("gomomomock: Collector.GetPaymentStatus called but GetPaymentStatusFunc is not set")
+	}
+	return m.GetPaymentStatusFunc(ctx, referenceID)
+}
+
+// GetAccountBalance records the call and calls GetAccountBalanceFunc
+func (m *Collector) GetAccountBalance(ctx context.Context) (string, string, error) {
+	m.record("GetAccountBalance")
+	if m.GetAccountBalanceFunc == nil {
+		panic("gomomomock: Collector.GetAccountBalance called but GetAccountBalanceFunc is not set")
+	}
+	return m.GetAccountBalanceFunc(ctx)
+}
+
+// GetBalance records the call and calls GetBalanceFunc
+func (m *Collector) GetBalance(ctx context.Context) (*gomomo.Balance, error) {
+	m.record("GetBalance")
+	if m.GetBalanceFunc == nil {
+		panic("gomomomock: Collector.GetBalance called but GetBalanceFunc is not set")
+	}
+	return m.GetBalanceFunc(ctx)
+}
+
+// GetAccountBalanceInCurrency records the call and calls GetAccountBalanceInCurrencyFunc
+func (m *Collector) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*gomomo.Balance, error) {
+	m.record("GetAccountBalanceInCurrency", currency)
+	if m.GetAccountBalanceInCurrencyFunc == nil {
+		panic("gomomomock: Collector.GetAccountBalanceInCurrency called but GetAccountBalanceInCurrencyFunc is not set")
+	}
+	return m.GetAccountBalanceInCurrencyFunc(ctx, currency)
+}
+
+// GetBalances records the call and calls GetBalancesFunc
+func (m *Collector) GetBalances(ctx context.Context) (map[string]*gomomo.Balance, error) {
+	m.record("GetBalances")
+	if m.GetBalancesFunc == nil {
+		panic("gomomomock: Collector.GetBalances called but GetBalancesFunc is not set")
+	}
+	return m.GetBalancesFunc(ctx)
+}
+
+// IsAccountHolderActive records the call and calls IsAccountHolderActiveFunc
+func (m *Collector) IsAccountHolderActive(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error) {
+	m.record("IsAccountHolderActive", accountHolder)
+	if m.IsAccountHolderActiveFunc == nil {
+		panic("gomomomock: Collector.IsAccountHolderActive called but IsAccountHolderActiveFunc is not set")
+	}
+	return m.IsAccountHolderActiveFunc(ctx, accountHolder)
+}
+
+// GetAccountHolderInfo records the call and calls GetAccountHolderInfoFunc
+func (m *Collector) GetAccountHolderInfo(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error) {
+	m.record("GetAccountHolderInfo", phone)
+	if m.GetAccountHolderInfoFunc == nil {
+		panic("gomomomock: Collector.GetAccountHolderInfo called but GetAccountHolderInfoFunc is not set")
+	}
+	return m.GetAccountHolderInfoFunc(ctx, phone)
+}
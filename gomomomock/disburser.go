@@ -0,0 +1,179 @@
+package gomomomock
+
+import (
+	"context"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Disburser is a mock gomomo.Disburser. Each method records its call and delegates
+// to the matching Func field, panicking if it is not set.
+type Disburser struct {
+	Recorder
+
+	// gomomo.Transferrer
+	TransferFunc        func(ctx context.Context, phone string, amount float64, opts *gomomo.TransferOptions) (string, error)
+	TransferMoneyFunc   func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.TransferOptions) (string, error)
+	TransferToPartyFunc func(ctx context.Context, payee gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.TransferOptions) (string, error)
+
+	// gomomo.TransferStatusChecker
+	GetTransferStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.StatusWaiter
+	WaitForFinalStatusFunc func(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.Depositor
+	DepositFunc          func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.DepositOptions) (string, error)
+	GetDepositStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.Refunder
+	RefundFunc          func(ctx context.Context, referenceIDToRefund string, amount gomomo.Money, opts *gomomo.RefundOptions) (string, error)
+	GetRefundStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.BalanceChecker
+	GetAccountBalanceFunc           func(ctx context.Context) (string, string, error)
+	GetBalanceFunc                  func(ctx context.Context) (*gomomo.Balance, error)
+	GetAccountBalanceInCurrencyFunc func(ctx context.Context, currency string) (*gomomo.Balance, error)
+	GetBalancesFunc                 func(ctx context.Context) (map[string]*gomomo.Balance, error)
+
+	// gomomo.AccountHolderChecker
+	IsAccountHolderActiveFunc func(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error)
+	GetAccountHolderInfoFunc  func(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error)
+}
+
+var _ gomomo.Disburser = (*Disburser)(nil)
+
+// Transfer records the call and calls TransferFunc
+func (m *Disburser) Transfer(ctx context.Context, phone string, amount float64, opts *gomomo.TransferOptions) (string, error) {
+	m.record("Transfer", phone, amount, opts)
+	if m.TransferFunc == nil {
+		panic("gomomomock: Disburser.Transfer called but TransferFunc is not set")
+	}
+	return m.TransferFunc(ctx, phone, amount, opts)
+}
+
+// TransferMoney records the call and calls TransferMoneyFunc
+func (m *Disburser) TransferMoney(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.TransferOptions) (string, error) {
+	m.record("TransferMoney", phone, amount, opts)
+	if m.TransferMoneyFunc == nil {
+		panic("gomomomock: Disburser.TransferMoney called but TransferMoneyFunc is not set")
+	}
+	return m.TransferMoneyFunc(ctx, phone, amount, opts)
+}
+
+// TransferToParty records the call and calls TransferToPartyFunc
+func (m *Disburser) TransferToParty(ctx context.Context, payee gomomo.PartyInfo, amount gomomo.Money, opts *gomomo.TransferOptions) (string, error) {
+	m.record("TransferToParty", payee, amount, opts)
+	if m.TransferToPartyFunc == nil {
+		panic("gomomomock: Disburser.TransferToParty called but TransferToPartyFunc is not set")
+	}
+	return m.TransferToPartyFunc(ctx, payee, amount, opts)
+}
+
+// GetTransferStatus records the call and calls GetTransferStatusFunc
+func (m *Disburser) GetTransferStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetTransferStatus", referenceID)
+	if m.GetTransferStatusFunc == nil {
+		panic("gomomomock: Disburser.GetTransferStatus called but GetTransferStatusFunc is not set")
+	}
+	return m.GetTransferStatusFunc(ctx, referenceID)
+}
+
+// WaitForFinalStatus records the call and calls WaitForFinalStatusFunc
+func (m *Disburser) WaitForFinalStatus(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error) {
+	m.record("WaitForFinalStatus", referenceID, opts)
+	if m.WaitForFinalStatusFunc == nil {
+		panic("gomomomock: Disburser.WaitForFinalStatus called but WaitForFinalStatusFunc is not set")
+	}
+	return m.WaitForFinalStatusFunc(ctx, referenceID, opts)
+}
+
+// Deposit records the call and calls DepositFunc
+func (m *Disburser) Deposit(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.DepositOptions) (string, error) {
+	m.record("Deposit", phone, amount, opts)
+	if m.DepositFunc == nil {
+		panic("gomomomock: Disburser.Deposit called but DepositFunc is not set")
+	}
+	return m.DepositFunc(ctx, phone, amount, opts)
+}
+
+// GetDepositStatus records the call and calls GetDepositStatusFunc
+func (m *Disburser) GetDepositStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetDepositStatus", referenceID)
+	if m.GetDepositStatusFunc == nil {
+		panic("gomomomock: Disburser.GetDepositStatus called but GetDepositStatusFunc is not set")
+	}
+	return m.GetDepositStatusFunc(ctx, referenceID)
+}
+
+// Refund records the call and calls RefundFunc
+func (m *Disburser) Refund(ctx context.Context, referenceIDToRefund string, amount gomomo.Money, opts *gomomo.RefundOptions) (string, error) {
+	m.record("Refund", referenceIDToRefund, amount, opts)
+	if m.RefundFunc == nil {
+		panic("gomomomock: Disburser.Refund called but RefundFunc is not set")
+	}
+	return m.RefundFunc(ctx, referenceIDToRefund, amount, opts)
+}
+
+// GetRefundStatus records the call and calls GetRefundStatusFunc
+func (m *Disburser) GetRefundStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetRefundStatus", referenceID)
+	if m.GetRefundStatusFunc == nil {
+		panic("gomomomock: Disburser.GetRefundStatus called but GetRefundStatusFunc is not set")
+	}
+	return m.GetRefundStatusFunc(ctx, referenceID)
+}
+
+// GetAccountBalance records the call and calls GetAccountBalanceFunc
+func (m *Disburser) GetAccountBalance(ctx context.Context) (string, string, error) {
+	m.record("GetAccountBalance")
+	if m.GetAccountBalanceFunc == nil {
+		panic("gomomomock: Disburser.GetAccountBalance called but GetAccountBalanceFunc is not set")
+	}
+	return m.GetAccountBalanceFunc(ctx)
+}
+
+// GetBalance records the call and calls GetBalanceFunc
+func (m *Disburser) GetBalance(ctx context.Context) (*gomomo.Balance, error) {
+	m.record("GetBalance")
+	if m.GetBalanceFunc == nil {
+		panic("gomomomock: Disburser.GetBalance called but GetBalanceFunc is not set")
+	}
+	return m.GetBalanceFunc(ctx)
+}
+
+// GetAccountBalanceInCurrency records the call and calls GetAccountBalanceInCurrencyFunc
+func (m *Disburser) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*gomomo.Balance, error) {
+	m.record("GetAccountBalanceInCurrency", currency)
+	if m.GetAccountBalanceInCurrencyFunc == nil {
+		panic("gomomomock: Disburser.GetAccountBalanceInCurrency called but GetAccountBalanceInCurrencyFunc is not set")
+	}
+	return m.GetAccountBalanceInCurrencyFunc(ctx, currency)
+}
+
+// GetBalances records the call and calls GetBalancesFunc
+func (m *Disburser) GetBalances(ctx context.Context) (map[string]*gomomo.Balance, error) {
+	m.record("GetBalances")
+	if m.GetBalancesFunc == nil {
+		panic("gomomomock: Disburser.GetBalances called but GetBalancesFunc is not set")
+	}
+	return m.GetBalancesFunc(ctx)
+}
+
+// IsAccountHolderActive records the call and calls IsAccountHolderActiveFunc
+func (m *Disburser) IsAccountHolderActive(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error) {
+	m.record("IsAccountHolderActive", accountHolder)
+	if m.IsAccountHolderActiveFunc == nil {
+		panic("gomomomock: Disburser.IsAccountHolderActive called but IsAccountHolderActiveFunc is not set")
+	}
+	return m.IsAccountHolderActiveFunc(ctx, accountHolder)
+}
+
+// GetAccountHolderInfo records the call and calls GetAccountHolderInfoFunc
+func (m *Disburser) GetAccountHolderInfo(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error) {
+	m.record("GetAccountHolderInfo", phone)
+	if m.GetAccountHolderInfoFunc == nil {
+		panic("gomomomock: Disburser.GetAccountHolderInfo called but GetAccountHolderInfoFunc is not set")
+	}
+	return m.GetAccountHolderInfoFunc(ctx, phone)
+}
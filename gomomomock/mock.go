@@ -0,0 +1,68 @@
+// Package gomomomock provides mock implementations of the gomomo service
+// interfaces for unit tests. The product mocks also satisfy the role
+// interfaces their product embeds, such as gomomo.BalanceChecker, with the
+// Func fields grouped by role. Each mock records its calls and delegates to
+// per-method Func fields:
+//
+//	collector := &gomomomock.Collector{
+//		RequestToPayMoneyFunc: func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error) {
+//			return "ref-1", nil
+//		},
+//	}
+//	client := &gomomo.MoMoClient{Collection: collector}
+//
+//	// ... exercise code using client ...
+//
+//	calls := collector.CallsTo("RequestToPayMoney")
+package gomomomock
+
+import "sync"
+
+// Call is a recorded method call
+type Call struct {
+	Method string
+	Args   []interface{} // Arguments after the context, in order
+}
+
+// Recorder records the calls made to a mock. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns all recorded calls in order
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to a method in order
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// Reset forgets all recorded calls
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
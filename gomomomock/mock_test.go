@@ -0,0 +1,110 @@
+package gomomomock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Role interfaces each product mock must satisfy
+var (
+	_ gomomo.PaymentRequester         = (*Collector)(nil)
+	_ gomomo.TransactionStatusChecker = (*Collector)(nil)
+	_ gomomo.BalanceChecker           = (*Collector)(nil)
+	_ gomomo.Refunder                 = (*Disburser)(nil)
+	_ gomomo.TransferStatusChecker    = (*Disburser)(nil)
+	_ gomomo.CashTransferrer          = (*Remitter)(nil)
+	_ gomomo.AccountHolderValidator   = (*Remitter)(nil)
+	_ gomomo.TokenProvider            = (*Authenticator)(nil)
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	amount := gomomo.NewMoney(1000, "EUR")
+
+	collector := &Collector{
+		RequestToPayMoneyFunc: func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RequestToPayOptions) (string, error) {
+			return "ref-" + phone, nil
+		},
+		GetBalanceFunc: func(ctx context.Context) (*gomomo.Balance, error) {
+			return &gomomo.Balance{AvailableBalance: "10.00", Currency: "EUR", Available: amount}, nil
+		},
+	}
+
+	// Used through role interfaces
+	var requester gomomo.PaymentRequester = collector
+	var balances gomomo.BalanceChecker = collector
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"RequestToPayMoney", func() error {
+			_, err := requester.RequestToPayMoney(ctx, "256772123456", amount, nil)
+			return err
+		}},
+		{"GetBalance", func() error {
+			_, err := balances.GetBalance(ctx)
+			return err
+		}},
+		{"RequestToPayMoney", func() error {
+			_, err := requester.RequestToPayMoney(ctx, "256772123457", amount, nil)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+	}
+
+	calls := collector.Calls()
+	if len(calls) != len(tests) {
+		t.Fatalf("recorded %d calls, want %d", len(calls), len(tests))
+	}
+	for i, tt := range tests {
+		if calls[i].Method != tt.name {
+			t.Errorf("call %d = %s, want %s", i, calls[i].Method, tt.name)
+		}
+	}
+
+	payments := collector.CallsTo("RequestToPayMoney")
+	if len(payments) != 2 || payments[1].Args[0] != "256772123457" || payments[1].Args[1] != amount {
+		t.Errorf("CallsTo(RequestToPayMoney) = %+v", payments)
+	}
+
+	collector.Reset()
+	if calls := collector.Calls(); len(calls) != 0 {
+		t.Errorf("after Reset recorded %d calls", len(calls))
+	}
+}
+
+func TestUnsetFuncPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"Collector.GetTransactionStatus", func() { (&Collector{}).GetTransactionStatus(context.Background(), "ref") }},
+		{"Disburser.Refund", func() { (&Disburser{}).Refund(context.Background(), "ref", gomomo.NewMoney(1, "EUR"), nil) }},
+		{"Remitter.GetBalances", func() { (&Remitter{}).GetBalances(context.Background()) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.call()
+		})
+	}
+
+	// InvalidateToken has no result, so an unset Func is a no-op
+	auth := &Authenticator{}
+	auth.InvalidateToken("collection")
+	if len(auth.CallsTo("InvalidateToken")) != 1 {
+		t.Error("InvalidateToken was not recorded")
+	}
+}
@@ -0,0 +1,149 @@
+package gomomomock
+
+import (
+	"context"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Remitter is a mock gomomo.Remitter. Each method records its call and delegates
+// to the matching Func field, panicking if it is not set.
+type Remitter struct {
+	Recorder
+
+	// gomomo.RemittanceTransferrer
+	TransferFunc func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RemittanceTransferOptions) (string, error)
+
+	// gomomo.TransferStatusChecker
+	GetTransferStatusFunc func(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.StatusWaiter
+	WaitForFinalStatusFunc func(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error)
+
+	// gomomo.CashTransferrer
+	CashTransferFunc          func(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.CashTransferOptions) (string, error)
+	GetCashTransferStatusFunc func(ctx context.Context, referenceID string) (*gomomo.CashTransferStatusResponse, error)
+
+	// gomomo.BalanceChecker
+	GetAccountBalanceFunc           func(ctx context.Context) (string, string, error)
+	GetBalanceFunc                  func(ctx context.Context) (*gomomo.Balance, error)
+	GetAccountBalanceInCurrencyFunc func(ctx context.Context, currency string) (*gomomo.Balance, error)
+	GetBalancesFunc                 func(ctx context.Context) (map[string]*gomomo.Balance, error)
+
+	// gomomo.AccountHolderValidator
+	ValidateAccountHolderFunc func(ctx context.Context, phone string) (bool, error)
+
+	// gomomo.AccountHolderChecker
+	IsAccountHolderActiveFunc func(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error)
+	GetAccountHolderInfoFunc  func(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error)
+}
+
+var _ gomomo.Remitter = (*Remitter)(nil)
+
+// Transfer records the call and calls TransferFunc
+func (m *Remitter) Transfer(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.RemittanceTransferOptions) (string, error) {
+	m.record("Transfer", phone, amount, opts)
+	if m.TransferFunc == nil {
+		panic("gomomomock: Remitter.Transfer called but TransferFunc is not set")
+	}
+	return m.TransferFunc(ctx, phone, amount, opts)
+}
+
+// GetTransferStatus records the call and calls GetTransferStatusFunc
+func (m *Remitter) GetTransferStatus(ctx context.Context, referenceID string) (*gomomo.TransactionStatusResponse, error) {
+	m.record("GetTransferStatus", referenceID)
+	if m.GetTransferStatusFunc == nil {
+		panic("gomomomock: Remitter.GetTransferStatus called but GetTransferStatusFunc is not set")
+	}
+	return m.GetTransferStatusFunc(ctx, referenceID)
+}
+
+// WaitForFinalStatus records the call and calls WaitForFinalStatusFunc
+func (m *Remitter) WaitForFinalStatus(ctx context.Context, referenceID string, opts *gomomo.WaitOptions) (*gomomo.TransactionStatusResponse, error) {
+	m.record("WaitForFinalStatus", referenceID, opts)
+	if m.WaitForFinalStatusFunc == nil {
+		panic("gomomomock: Remitter.WaitForFinalStatus called but WaitForFinalStatusFunc is not set")
+	}
+	return m.WaitForFinalStatusFunc(ctx, referenceID, opts)
+}
+
+// CashTransfer records the call and calls CashTransferFunc
+func (m *Remitter) CashTransfer(ctx context.Context, phone string, amount gomomo.Money, opts *gomomo.CashTransferOptions) (string, error) {
+	m.record("CashTransfer", phone, amount, opts)
+	if m.CashTransferFunc == nil {
+		panic("gomomomock: Remitter.CashTransfer called but CashTransferFunc is not set")
+	}
+	return m.CashTransferFunc(ctx, phone, amount, opts)
+}
+
+// GetCashTransferStatus records the call and calls GetCashTransferStatusFunc
+func (m *Remitter) GetCashTransferStatus(ctx context.Context, referenceID string) (*gomomo.CashTransferStatusResponse, error) {
+	m.record("GetCashTransferStatus", referenceID)
+	if m.GetCashTransferStatusFunc == nil {
+		panic("gomomomock: Remitter.GetCashTransferStatus called but GetCashTransferStatusFunc is not set")
+	}
+	return m.GetCashTransferStatusFunc(ctx, referenceID)
+}
+
+// GetAccountBalance records the call and calls GetAccountBalanceFunc
+func (m *Remitter) GetAccountBalance(ctx context.Context) (string, string, error) {
+	m.record("GetAccountBalance")
+	if m.GetAccountBalanceFunc == nil {
+		panic("gomomomock: Remitter.GetAccountBalance called but GetAccountBalanceFunc is not set")
+	}
+	return m.GetAccountBalanceFunc(ctx)
+}
+
+// GetBalance records the call and calls GetBalanceFunc
+func (m *Remitter) GetBalance(ctx context.Context) (*gomomo.Balance, error) {
+	m.record("GetBalance")
+	if m.GetBalanceFunc == nil {
+		panic("gomomomock: Remitter.GetBalance called but GetBalanceFunc is not set")
+	}
+	return m.GetBalanceFunc(ctx)
+}
+
+// GetAccountBalanceInCurrency records the call and calls GetAccountBalanceInCurrencyFunc
+func (m *Remitter) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*gomomo.Balance, error) {
+	m.record("GetAccountBalanceInCurrency", currency)
+	if m.GetAccountBalanceInCurrencyFunc == nil {
+		panic("gomomomock: Remitter.GetAccountBalanceInCurrency called but GetAccountBalanceInCurrencyFunc is not set")
+	}
+	return m.GetAccountBalanceInCurrencyFunc(ctx, currency)
+}
+
+// GetBalances records the call and calls GetBalancesFunc
+func (m *Remitter) GetBalances(ctx context.Context) (map[string]*gomomo.Balance, error) {
+	m.record("GetBalances")
+	if m.GetBalancesFunc == nil {
+		panic("gomomomock: Remitter.GetBalances called but GetBalancesFunc is not set")
+	}
+	return m.GetBalancesFunc(ctx)
+}
+
+// ValidateAccountHolder records the call and calls ValidateAccountHolderFunc
+func (m *Remitter) ValidateAccountHolder(ctx context.Context, phone string) (bool, error) {
+	m.record("ValidateAccountHolder", phone)
+	if m.ValidateAccountHolderFunc == nil {
+		panic("gomomomock: Remitter.ValidateAccountHolder called but ValidateAccountHolderFunc is not set")
+	}
+	return m.ValidateAccountHolderFunc(ctx, phone)
+}
+
+// IsAccountHolderActive records the call and calls IsAccountHolderActiveFunc
+func (m *Remitter) IsAccountHolderActive(ctx context.Context, accountHolder gomomo.PartyInfo) (bool, error) {
+	m.record("IsAccountHolderActive", accountHolder)
+	if m.IsAccountHolderActiveFunc == nil {
+		panic("gomomomock: Remitter.IsAccountHolderActive called but IsAccountHolderActiveFunc is not set")
+	}
+	return m.IsAccountHolderActiveFunc(ctx, accountHolder)
+}
+
+// GetAccountHolderInfo records the call and calls GetAccountHolderInfoFunc
+func (m *Remitter) GetAccountHolderInfo(ctx context.Context, phone string) (*gomomo.AccountHolderInfo, error) {
+	m.record("GetAccountHolderInfo", phone)
+	if m.GetAccountHolderInfoFunc == nil {
+		panic("gomomomock: Remitter.GetAccountHolderInfo called but GetAccountHolderInfoFunc is not set")
+	}
+	return m.GetAccountHolderInfoFunc(ctx, phone)
+}
@@ -0,0 +1,39 @@
+package gomomo
+
+import (
+	"net/http"
+	"time"
+)
+
+// Call describes a single HTTP attempt made by the client. Request is a copy
+// kept for inspection only: changing it has no effect, since the body has
+// already been encoded into HTTPRequest. Interceptors that need to alter what
+// is sent modify HTTPRequest instead.
+type Call struct {
+	Request     Request       // Copy of the API request being made, read-only
+	HTTPRequest *http.Request // The HTTP request built from Request, sent as-is by the client
+	Attempt     int           // Attempt number, starting at 1
+	Latency     time.Duration // Time taken by the HTTP round trip, set once it completes
+}
+
+// Invoker sends a call and returns the HTTP response
+type Invoker func(call *Call) (*http.Response, error)
+
+// Interceptor wraps every HTTP attempt made by the client. It may inspect or
+// modify call.HTTPRequest before calling next, and inspect the response,
+// latency and error afterwards. It can also skip next entirely to return a
+// canned response or error.
+type Interceptor func(call *Call, next Invoker) (*http.Response, error)
+
+// chainInterceptors composes interceptors around an invoker. The first
+// interceptor is the outermost one.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := invoker
+		invoker = func(call *Call) (*http.Response, error) {
+			return interceptor(call, next)
+		}
+	}
+	return invoker
+}
@@ -0,0 +1,83 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	var order []string
+	named := func(name string) Interceptor {
+		return func(call *Call, next Invoker) (*http.Response, error) {
+			order = append(order, name+" before")
+			resp, err := next(call)
+			order = append(order, name+" after")
+			return resp, err
+		}
+	}
+
+	client := NewClient(newTestConfig(t, srv.URL, WithInterceptors(named("outer"), named("inner"))))
+	if err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, nil); err != nil {
+		t.Fatalf("DoRequest: %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestInterceptorRequestIsReadOnly(t *testing.T) {
+	var gotHeader, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Test")
+		gotPath = r.URL.Path
+		fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	interceptor := func(call *Call, next Invoker) (*http.Response, error) {
+		call.Request.Path = "/changed"
+		call.Request.Headers["X-Test"] = "changed"
+		call.HTTPRequest.Header.Set("X-Added", "yes")
+		return next(call)
+	}
+
+	headers := map[string]string{"X-Test": "original"}
+	client := NewClient(newTestConfig(t, srv.URL, WithInterceptors(interceptor)))
+	if err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x", Headers: headers}, nil); err != nil {
+		t.Fatalf("DoRequest: %v", err)
+	}
+
+	if gotPath != "/x" || gotHeader != "original" {
+		t.Errorf("sent %s with X-Test=%q, want /x with the original header", gotPath, gotHeader)
+	}
+	if headers["X-Test"] != "original" {
+		t.Errorf("caller's headers were modified: %v", headers)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	canned := func(call *Call, next Invoker) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	client := NewClient(newTestConfig(t, "http://127.0.0.1:1", WithInterceptors(canned)))
+	err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, nil)
+	if err == nil {
+		t.Fatal("DoRequest succeeded, want the canned 404")
+	}
+}
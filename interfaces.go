@@ -0,0 +1,165 @@
+package gomomo
+
+import "context"
+
+// TokenProvider issues access tokens for the MoMo products
+type TokenProvider interface {
+	// GetAccessToken returns a valid access token for the product
+	GetAccessToken(ctx context.Context, product string) (string, error)
+	// InvalidateToken drops the cached token for the product
+	InvalidateToken(product string)
+}
+
+// Authenticator provisions API credentials and issues access tokens
+type Authenticator interface {
+	TokenProvider
+
+	// CreateAPIUser creates a new API user (sandbox only)
+	CreateAPIUser(ctx context.Context) (string, error)
+	// CreateAPIKey creates an API key for an API user (sandbox only)
+	CreateAPIKey(ctx context.Context, apiUserID string) (string, error)
+}
+
+// PaymentRequester asks account holders to pay through the Collection product
+type PaymentRequester interface {
+	// Deprecated: use RequestToPayMoney, which takes an exact amount
+	RequestToPay(ctx context.Context, phone string, amount float64, opts *RequestToPayOptions) (string, error)
+	RequestToPayMoney(ctx context.Context, phone string, amount Money, opts *RequestToPayOptions) (string, error)
+	RequestToPayFromParty(ctx context.Context, payer PartyInfo, amount Money, opts *RequestToPayOptions) (string, error)
+}
+
+// TransactionStatusChecker checks the status of payment requests
+type TransactionStatusChecker interface {
+	GetTransactionStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+}
+
+// WithdrawalRequester asks account holders to approve cash-outs
+type WithdrawalRequester interface {
+	RequestToWithdraw(ctx context.Context, phone string, amount Money, opts *RequestToWithdrawOptions) (string, error)
+	GetWithdrawStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+}
+
+// PreApprovalManager manages pre-approvals for recurring payments
+type PreApprovalManager interface {
+	CreatePreApproval(ctx context.Context, phone string, opts *PreApprovalOptions) (string, error)
+	GetPreApprovalStatus(ctx context.Context, preApprovalID string) (*PreApprovalStatusResponse, error)
+	GetApprovedPreApprovals(ctx context.Context, accountHolder PartyInfo) ([]ApprovedPreApproval, error)
+	CancelPreApproval(ctx context.Context, preApprovalID string) error
+}
+
+// InvoiceManager issues and cancels payable invoices
+type InvoiceManager interface {
+	CreateInvoice(ctx context.Context, intendedPayer PartyInfo, amount Money, opts *InvoiceOptions) (string, error)
+	GetInvoiceStatus(ctx context.Context, referenceID string) (*InvoiceStatusResponse, error)
+	CancelInvoice(ctx context.Context, referenceID, externalID string) error
+}
+
+// BillPayer pays external bills to service providers
+type BillPayer interface {
+	CreatePayment(ctx context.Context, customerReference, serviceProviderUserName string, amount Money, opts *PaymentOptions) (string, error)
+	GetPaymentStatus(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)
+}
+
+// Transferrer sends money to account holders through the Disbursement product
+type Transferrer interface {
+	// Deprecated: use TransferMoney, which takes an exact amount
+	Transfer(ctx context.Context, phone string, amount float64, opts *TransferOptions) (string, error)
+	TransferMoney(ctx context.Context, phone string, amount Money, opts *TransferOptions) (string, error)
+	TransferToParty(ctx context.Context, payee PartyInfo, amount Money, opts *TransferOptions) (string, error)
+}
+
+// TransferStatusChecker checks the status of transfers
+type TransferStatusChecker interface {
+	GetTransferStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+}
+
+// Depositor deposits money into account holders' wallets
+type Depositor interface {
+	Deposit(ctx context.Context, phone string, amount Money, opts *DepositOptions) (string, error)
+	GetDepositStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+}
+
+// Refunder refunds successful payment requests
+type Refunder interface {
+	Refund(ctx context.Context, referenceIDToRefund string, amount Money, opts *RefundOptions) (string, error)
+	GetRefundStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+}
+
+// RemittanceTransferrer sends cross-border payouts to account holders
+type RemittanceTransferrer interface {
+	Transfer(ctx context.Context, phone string, amount Money, opts *RemittanceTransferOptions) (string, error)
+}
+
+// CashTransferrer sends cash transfers collected by the recipient
+type CashTransferrer interface {
+	CashTransfer(ctx context.Context, phone string, amount Money, opts *CashTransferOptions) (string, error)
+	GetCashTransferStatus(ctx context.Context, referenceID string) (*CashTransferStatusResponse, error)
+}
+
+// StatusWaiter polls a transaction until it reaches a final status
+type StatusWaiter interface {
+	WaitForFinalStatus(ctx context.Context, referenceID string, opts *WaitOptions) (*TransactionStatusResponse, error)
+}
+
+// BalanceChecker reads the balances of a product's account
+type BalanceChecker interface {
+	GetAccountBalance(ctx context.Context) (string, string, error)
+	GetBalance(ctx context.Context) (*Balance, error)
+	GetAccountBalanceInCurrency(ctx context.Context, currency string) (*Balance, error)
+	GetBalances(ctx context.Context) (map[string]*Balance, error)
+}
+
+// AccountHolderChecker looks up account holders
+type AccountHolderChecker interface {
+	IsAccountHolderActive(ctx context.Context, accountHolder PartyInfo) (bool, error)
+	GetAccountHolderInfo(ctx context.Context, phone string) (*AccountHolderInfo, error)
+}
+
+// AccountHolderValidator checks that a phone number belongs to an active
+// account holder
+type AccountHolderValidator interface {
+	ValidateAccountHolder(ctx context.Context, phone string) (bool, error)
+}
+
+// Collector receives payments through the Collection product
+type Collector interface {
+	PaymentRequester
+	TransactionStatusChecker
+	StatusWaiter
+	WithdrawalRequester
+	PreApprovalManager
+	InvoiceManager
+	BillPayer
+	BalanceChecker
+	AccountHolderChecker
+}
+
+// Disburser sends money through the Disbursement product
+type Disburser interface {
+	Transferrer
+	TransferStatusChecker
+	StatusWaiter
+	Depositor
+	Refunder
+	BalanceChecker
+	AccountHolderChecker
+}
+
+// Remitter sends cross-border payouts through the Remittance product
+type Remitter interface {
+	RemittanceTransferrer
+	TransferStatusChecker
+	StatusWaiter
+	CashTransferrer
+	BalanceChecker
+	AccountHolderValidator
+	AccountHolderChecker
+}
+
+// The concrete services satisfy the interfaces
+var (
+	_ Authenticator = (*AuthService)(nil)
+	_ Collector     = (*CollectionService)(nil)
+	_ Disburser     = (*DisbursementService)(nil)
+	_ Remitter      = (*RemittanceService)(nil)
+)
@@ -0,0 +1,178 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceStatus represents the status of an invoice
+type InvoiceStatus string
+
+const (
+	// InvoicePending means the invoice has not been paid yet
+	InvoicePending InvoiceStatus = "PENDING"
+	// InvoiceSuccessful means the invoice was paid
+	InvoiceSuccessful InvoiceStatus = "SUCCESSFUL"
+	// InvoiceFailed means the invoice could not be paid
+	InvoiceFailed InvoiceStatus = "FAILED"
+	// InvoiceCancelled means the invoice was cancelled
+	InvoiceCancelled InvoiceStatus = "CANCELLED"
+)
+
+// InvoiceOptions contains optional parameters for invoices
+type InvoiceOptions struct {
+	ExternalID  string        // Custom external ID (generated if empty)
+	ReferenceID string        // Custom reference ID (generated if empty)
+	Description string        // Description shown to the payer
+	Validity    time.Duration // How long the invoice can be paid (1 hour if zero)
+	Payee       *PartyInfo    // Party receiving the payment, if not the merchant
+	CallbackURL string        // Callback URL (built from the config's callback path if empty)
+}
+
+// InvoicePayload represents the payload for creating an invoice
+type InvoicePayload struct {
+	ExternalID       string     `json:"externalId"`
+	Amount           string     `json:"amount"`
+	Currency         string     `json:"currency"`
+	ValidityDuration string     `json:"validityDuration"`
+	IntendedPayer    PartyInfo  `json:"intendedPayer"`
+	Payee            *PartyInfo `json:"payee,omitempty"`
+	Description      string     `json:"description,omitempty"`
+}
+
+// InvoiceErrorReason explains why an invoice failed
+type InvoiceErrorReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// InvoiceStatusResponse represents the response from an invoice status check
+type InvoiceStatusResponse struct {
+	ReferenceID      string              `json:"referenceId"`
+	ExternalID       string              `json:"externalId"`
+	Amount           string              `json:"amount"`
+	Currency         string              `json:"currency"`
+	Status           InvoiceStatus       `json:"status"`
+	PaymentReference string              `json:"paymentReference,omitempty"`
+	InvoiceID        string              `json:"invoiceId,omitempty"`
+	ExpiryDateTime   string              `json:"expiryDateTime,omitempty"`
+	PayeeFirstName   string              `json:"payeeFirstName,omitempty"`
+	PayeeLastName    string              `json:"payeeLastName,omitempty"`
+	ErrorReason      *InvoiceErrorReason `json:"errorReason,omitempty"`
+	IntendedPayer    PartyInfo           `json:"intendedPayer"`
+	Description      string              `json:"description,omitempty"`
+}
+
+// Money returns the invoiced amount as an exact Money value
+func (r *InvoiceStatusResponse) Money() (Money, error) {
+	return ParseMoney(r.Amount, r.Currency)
+}
+
+// CreateInvoice issues an invoice of an exact amount that the intended payer
+// can pay from their wallet. The returned reference ID identifies the invoice.
+func (s *CollectionService) CreateInvoice(ctx context.Context, intendedPayer PartyInfo, amount Money, opts *InvoiceOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+
+	payer, err := s.config.normalizeParty(intendedPayer)
+	if err != nil {
+		return "", err
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &InvoiceOptions{}
+	}
+
+	var payee *PartyInfo
+	if opts.Payee != nil {
+		normalized, err := s.config.normalizeParty(*opts.Payee)
+		if err != nil {
+			return "", err
+		}
+		payee = &normalized
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	validity := opts.Validity
+	if validity <= 0 {
+		validity = time.Hour
+	}
+
+	// Create request payload
+	payload := InvoicePayload{
+		ExternalID:       externalID,
+		Amount:           amount.AmountString(),
+		Currency:         amount.Currency,
+		ValidityDuration: strconv.FormatInt(int64(validity/time.Second), 10),
+		IntendedPayer:    payer,
+		Payee:            payee,
+		Description:      opts.Description,
+	}
+
+	err = s.post(ctx, "/collection/v2_0/invoice", payload, referenceID, "", CallbackInvoice, opts.CallbackURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating invoice: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetInvoiceStatus checks the status of an invoice
+func (s *CollectionService) GetInvoiceStatus(ctx context.Context, referenceID string) (*InvoiceStatusResponse, error) {
+	var result InvoiceStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/collection/v2_0/invoice/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking invoice status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CancelInvoice cancels an unpaid invoice. The external ID must be the one
+// the invoice was created with.
+func (s *CollectionService) CancelInvoice(ctx context.Context, referenceID, externalID string) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	req := Request{
+		Method: http.MethodDelete,
+		Path:   fmt.Sprintf("/collection/v2_0/invoice/%s", referenceID),
+		Body: map[string]string{
+			"externalId": externalID,
+		},
+		Headers: map[string]string{
+			"Authorization":             "Bearer " + token,
+			"X-Reference-Id":            uuid.New().String(),
+			"X-Target-Environment":      s.config.TargetEnvironment,
+			"Ocp-Apim-Subscription-Key": s.config.SubscriptionKey,
+		},
+	}
+
+	err = s.client.DoRequest(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error cancelling invoice: %w", err)
+	}
+
+	return nil
+}
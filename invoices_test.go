@@ -0,0 +1,199 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// invoiceRequest is a request received by invoiceServer
+type invoiceRequest struct {
+	method string
+	path   string
+	body   []byte
+}
+
+// invoiceServer serves the collection token endpoint and answers every other
+// request with the given status and body, recording what it received
+func invoiceServer(t *testing.T, status int, body string) (*CollectionService, *[]invoiceRequest) {
+	t.Helper()
+
+	var requests []invoiceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/collection/token/" {
+			fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		requests = append(requests, invoiceRequest{r.Method, r.URL.Path, data})
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := newTestConfig(t, srv.URL)
+	client := NewClient(config)
+	return NewCollectionService(client, config, NewAuthService(client, config)), &requests
+}
+
+func TestCreateInvoice(t *testing.T) {
+	payer := PartyInfo{PartyIDType: MSISDN, PartyID: "46733123454"}
+	payee := &PartyInfo{PartyIDType: Email, PartyID: "shop@example.com"}
+
+	tests := []struct {
+		name string
+		opts *InvoiceOptions
+		want InvoicePayload
+	}{
+		{
+			"defaults",
+			&InvoiceOptions{ExternalID: "inv-1"},
+			InvoicePayload{
+				ExternalID:       "inv-1",
+				Amount:           "25.00",
+				Currency:         "EUR",
+				ValidityDuration: "3600",
+				IntendedPayer:    payer,
+			},
+		},
+		{
+			"validity, payee and description",
+			&InvoiceOptions{ExternalID: "inv-2", Validity: 90 * time.Minute, Payee: payee, Description: "March rent"},
+			InvoicePayload{
+				ExternalID:       "inv-2",
+				Amount:           "25.00",
+				Currency:         "EUR",
+				ValidityDuration: "5400",
+				IntendedPayer:    payer,
+				Payee:            payee,
+				Description:      "March rent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, requests := invoiceServer(t, http.StatusAccepted, "")
+
+			referenceID, err := collection.CreateInvoice(context.Background(), payer, NewMoney(2500, "EUR"), tt.opts)
+			if err != nil {
+				t.Fatalf("CreateInvoice: %v", err)
+			}
+			if referenceID == "" {
+				t.Error("CreateInvoice returned an empty reference ID")
+			}
+
+			if len(*requests) != 1 {
+				t.Fatalf("got %d API requests, want 1", len(*requests))
+			}
+			req := (*requests)[0]
+			if req.method != http.MethodPost || req.path != "/collection/v2_0/invoice" {
+				t.Errorf("request = %s %s, want POST /collection/v2_0/invoice", req.method, req.path)
+			}
+
+			var payload InvoicePayload
+			if err := json.Unmarshal(req.body, &payload); err != nil {
+				t.Fatalf("decoding payload %s: %v", req.body, err)
+			}
+			if !reflect.DeepEqual(payload, tt.want) {
+				t.Errorf("payload = %+v, want %+v", payload, tt.want)
+			}
+
+			var raw map[string]json.RawMessage
+			json.Unmarshal(req.body, &raw)
+			if _, ok := raw["payee"]; ok != (tt.opts.Payee != nil) {
+				t.Errorf("payee sent = %v, want %v", ok, tt.opts.Payee != nil)
+			}
+		})
+	}
+}
+
+func TestGetInvoiceStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus InvoiceStatus
+		wantReason *InvoiceErrorReason
+	}{
+		{
+			"pending",
+			`{"referenceId":"ref-1","externalId":"inv-1","amount":"25.00","currency":"EUR","status":"PENDING",
+			  "intendedPayer":{"partyIdType":"MSISDN","partyId":"46733123454"}}`,
+			InvoicePending,
+			nil,
+		},
+		{
+			"failed",
+			`{"referenceId":"ref-1","externalId":"inv-1","amount":"25.00","currency":"EUR","status":"FAILED",
+			  "errorReason":{"code":"PAYER_NOT_FOUND","message":"Payer not found"},
+			  "intendedPayer":{"partyIdType":"MSISDN","partyId":"46733123454"}}`,
+			InvoiceFailed,
+			&InvoiceErrorReason{Code: "PAYER_NOT_FOUND", Message: "Payer not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, requests := invoiceServer(t, http.StatusOK, tt.body)
+
+			status, err := collection.GetInvoiceStatus(context.Background(), "ref-1")
+			if err != nil {
+				t.Fatalf("GetInvoiceStatus: %v", err)
+			}
+
+			req := (*requests)[0]
+			if req.method != http.MethodGet || req.path != "/collection/v2_0/invoice/ref-1" {
+				t.Errorf("request = %s %s, want GET /collection/v2_0/invoice/ref-1", req.method, req.path)
+			}
+			if status.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", status.Status, tt.wantStatus)
+			}
+			if (status.ErrorReason == nil) != (tt.wantReason == nil) ||
+				(tt.wantReason != nil && *status.ErrorReason != *tt.wantReason) {
+				t.Errorf("ErrorReason = %+v, want %+v", status.ErrorReason, tt.wantReason)
+			}
+			if status.IntendedPayer.PartyID != "46733123454" {
+				t.Errorf("IntendedPayer = %+v", status.IntendedPayer)
+			}
+			if amount, err := status.Money(); err != nil || amount != NewMoney(2500, "EUR") {
+				t.Errorf("Money() = %v, %v; want 25.00 EUR", amount, err)
+			}
+		})
+	}
+}
+
+func TestCancelInvoice(t *testing.T) {
+	collection, requests := invoiceServer(t, http.StatusOK, "")
+
+	if err := collection.CancelInvoice(context.Background(), "ref-1", "inv-1"); err != nil {
+		t.Fatalf("CancelInvoice: %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d API requests, want 1", len(*requests))
+	}
+	req := (*requests)[0]
+	if req.method != http.MethodDelete || req.path != "/collection/v2_0/invoice/ref-1" {
+		t.Errorf("request = %s %s, want DELETE /collection/v2_0/invoice/ref-1", req.method, req.path)
+	}
+	if got := string(req.body); got != `{"externalId":"inv-1"}` {
+		t.Errorf("body = %s, want the external ID", got)
+	}
+}
+
+func TestCancelInvoiceError(t *testing.T) {
+	collection, _ := invoiceServer(t, http.StatusNotFound, `{"code":"RESOURCE_NOT_FOUND","message":"no invoice"}`)
+
+	err := collection.CancelInvoice(context.Background(), "ref-1", "inv-1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,206 @@
+package gomomo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Headers that are never logged in clear
+var secretHeaders = map[string]bool{
+	"Authorization":             true,
+	"Ocp-Apim-Subscription-Key": true,
+}
+
+// JSON fields that are never logged in clear
+var secretFields = map[string]bool{
+	"apiKey":       true,
+	"access_token": true,
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// loggingInterceptor logs every HTTP attempt with secrets redacted. Bodies are
+// only dumped when the logger has debug level enabled.
+func loggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(call *Call, next Invoker) (*http.Response, error) {
+		ctx := call.HTTPRequest.Context()
+		debug := logger.Enabled(ctx, slog.LevelDebug)
+
+		attrs := []slog.Attr{
+			slog.String("method", call.Request.Method),
+			slog.String("path", pathTemplate(call.Request.Path)),
+			slog.Int("attempt", call.Attempt),
+		}
+		if referenceID := call.Request.referenceID(); referenceID != "" {
+			attrs = append(attrs, slog.String("reference_id", referenceID))
+		}
+
+		if debug {
+			logger.LogAttrs(ctx, slog.LevelDebug, "momo request",
+				append(attrs,
+					slog.Any("headers", redactHeaders(call.HTTPRequest.Header)),
+					slog.String("body", requestBody(call.HTTPRequest)),
+				)...)
+		}
+
+		resp, err := next(call)
+
+		attrs = append(attrs, slog.Duration("latency", call.Latency))
+		if err != nil {
+			// Transport errors include the URL, which may contain an MSISDN
+			path := call.HTTPRequest.URL.Path
+			message := strings.ReplaceAll(err.Error(), path, pathTemplate(path))
+			logger.LogAttrs(ctx, slog.LevelWarn, "momo request failed", append(attrs, slog.String("error", message))...)
+			return resp, err
+		}
+
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if debug {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr == nil {
+				attrs = append(attrs, slog.String("body", redactBody(body)))
+			}
+		}
+
+		level := slog.LevelInfo
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, "momo response", attrs...)
+
+		return resp, err
+	}
+}
+
+// pathTemplate replaces identifiers in an API path with placeholders so that
+// paths can be grouped and never leak reference IDs or account holders
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case i > 0 && isPartyIDType(segments[i-1]):
+			segments[i] = "{accountHolderId}"
+		case uuidPattern.MatchString(segment):
+			segments[i] = "{referenceId}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isPartyIDType reports whether a path segment names a party ID type
+func isPartyIDType(segment string) bool {
+	switch PartyIDType(strings.ToUpper(segment)) {
+	case MSISDN, Email, Party:
+		return true
+	default:
+		return false
+	}
+}
+
+// redactHeaders returns a copy of the headers with secrets removed
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key := range header {
+		if secretHeaders[http.CanonicalHeaderKey(key)] {
+			result[key] = redacted
+		} else {
+			result[key] = header.Get(key)
+		}
+	}
+	return result
+}
+
+// requestBody returns the redacted body of an outgoing request
+func requestBody(req *http.Request) string {
+	return redactBody(readRequestBody(req))
+}
+
+// readRequestBody returns a copy of the body of an outgoing request
+func readRequestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// redactBody removes secrets and masks MSISDNs in a JSON body
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[non-JSON body, %d bytes]", len(body))
+	}
+
+	data, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+	return string(data)
+}
+
+// redactValue walks a decoded JSON value redacting secret fields and MSISDNs
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch {
+			case secretFields[key]:
+				v[key] = redacted
+			case isMSISDNField(key, v):
+				if s, ok := field.(string); ok {
+					v[key] = maskMSISDN(s)
+				}
+			default:
+				v[key] = redactValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isMSISDNField reports whether a JSON field holds an MSISDN, either by name
+// (msisdn, payerMsisdn, ...) or as the partyId of an MSISDN party
+func isMSISDNField(key string, object map[string]interface{}) bool {
+	if key == "partyId" {
+		return object["partyIdType"] == string(MSISDN)
+	}
+	return strings.HasSuffix(strings.ToLower(key), "msisdn")
+}
+
+// maskMSISDN hides the middle digits of a phone number, e.g. 231****89
+func maskMSISDN(msisdn string) string {
+	if len(msisdn) <= 5 {
+		return strings.Repeat("*", len(msisdn))
+	}
+	return msisdn[:3] + strings.Repeat("*", len(msisdn)-5) + msisdn[len(msisdn)-2:]
+}
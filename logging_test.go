@@ -0,0 +1,268 @@
+package gomomo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/collection/v1_0/requesttopay", "/collection/v1_0/requesttopay"},
+		{
+			"/collection/v1_0/requesttopay/0b7d5c1e-3f4a-4e8b-9c2d-1a2b3c4d5e6f",
+			"/collection/v1_0/requesttopay/{referenceId}",
+		},
+		{
+			"/collection/v1_0/accountholder/msisdn/46733123454/active",
+			"/collection/v1_0/accountholder/msisdn/{accountHolderId}/active",
+		},
+		{
+			"/collection/v1_0/accountholder/MSISDN/46733123454/active",
+			"/collection/v1_0/accountholder/MSISDN/{accountHolderId}/active",
+		},
+		{
+			"/collection/v1_0/preapprovals/email/payer@example.com",
+			"/collection/v1_0/preapprovals/email/{accountHolderId}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := pathTemplate(tt.path); got != tt.want {
+				t.Errorf("pathTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskMSISDN(t *testing.T) {
+	tests := []struct {
+		msisdn string
+		want   string
+	}{
+		{"46733123454", "467******54"},
+		{"231770000089", "231*******89"},
+		{"123456", "123*56"},
+		{"12345", "*****"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := maskMSISDN(tt.msisdn); got != tt.want {
+			t.Errorf("maskMSISDN(%q) = %q, want %q", tt.msisdn, got, tt.want)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", ``, ``},
+		{"non-JSON", `not json`, `[non-JSON body, 8 bytes]`},
+		{"api key", `{"apiKey":"secret","user":"u"}`, `{"apiKey":"[REDACTED]","user":"u"}`},
+		{"access token", `{"access_token":"tok","expires_in":3600}`, `{"access_token":"[REDACTED]","expires_in":3600}`},
+		{
+			"MSISDN party",
+			`{"payer":{"partyIdType":"MSISDN","partyId":"46733123454"}}`,
+			`{"payer":{"partyId":"467******54","partyIdType":"MSISDN"}}`,
+		},
+		{
+			"email party",
+			`{"payer":{"partyIdType":"EMAIL","partyId":"payer@example.com"}}`,
+			`{"payer":{"partyId":"payer@example.com","partyIdType":"EMAIL"}}`,
+		},
+		{"msisdn field", `{"msisdn":"46733123454"}`, `{"msisdn":"467******54"}`},
+		{"payerMsisdn field", `{"payerMsisdn":"46733123454"}`, `{"payerMsisdn":"467******54"}`},
+		{"PayeeMSISDN field", `{"PayeeMSISDN":"46733123454"}`, `{"PayeeMSISDN":"467******54"}`},
+		{
+			"nested array",
+			`{"items":[{"msisdn":"46733123454"},{"apiKey":"secret"}]}`,
+			`{"items":[{"msisdn":"467******54"},{"apiKey":"[REDACTED]"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactValueLeavesNonStringMSISDN(t *testing.T) {
+	value := map[string]interface{}{"payerMsisdn": float64(46733123454)}
+	got := redactValue(value).(map[string]interface{})
+	if got["payerMsisdn"] != float64(46733123454) {
+		t.Errorf("payerMsisdn = %v, want it unchanged", got["payerMsisdn"])
+	}
+}
+
+// logRecords decodes the JSON log lines written by a slog.JSONHandler
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"live-token","msisdn":"46733123454"}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(newTestConfig(t, srv.URL, WithLogger(logger)))
+
+	referenceID := "0b7d5c1e-3f4a-4e8b-9c2d-1a2b3c4d5e6f"
+	err := client.DoRequest(context.Background(), Request{
+		Method: http.MethodPost,
+		Path:   "/collection/v1_0/accountholder/msisdn/46733123454/active",
+		Body:   map[string]string{"apiKey": "secret", "payerMsisdn": "46733123454"},
+		Headers: map[string]string{
+			"Authorization":             "Bearer live-token",
+			"Ocp-Apim-Subscription-Key": "key",
+			"X-Reference-Id":            referenceID,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("DoRequest: %v", err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"live-token", "secret", `"key"`, "46733123454"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("log output contains %s:\n%s", secret, output)
+		}
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d log records, want 2:\n%s", len(records), output)
+	}
+
+	request, response := records[0], records[1]
+	if request["msg"] != "momo request" || response["msg"] != "momo response" {
+		t.Errorf("messages = %v, %v", request["msg"], response["msg"])
+	}
+	if want := "/collection/v1_0/accountholder/msisdn/{accountHolderId}/active"; request["path"] != want {
+		t.Errorf("path = %v, want %s", request["path"], want)
+	}
+	if request["reference_id"] != referenceID {
+		t.Errorf("reference_id = %v, want %s", request["reference_id"], referenceID)
+	}
+
+	headers, _ := request["headers"].(map[string]interface{})
+	for _, name := range []string{"Authorization", "Ocp-Apim-Subscription-Key"} {
+		if headers[name] != redacted {
+			t.Errorf("header %s = %v, want %s", name, headers[name], redacted)
+		}
+	}
+	if headers["X-Reference-Id"] != referenceID {
+		t.Errorf("header X-Reference-Id = %v, want %s", headers["X-Reference-Id"], referenceID)
+	}
+
+	if want := `{"apiKey":"[REDACTED]","payerMsisdn":"467******54"}`; request["body"] != want {
+		t.Errorf("request body = %v, want %s", request["body"], want)
+	}
+	if want := `{"access_token":"[REDACTED]","msisdn":"467******54"}`; response["body"] != want {
+		t.Errorf("response body = %v, want %s", response["body"], want)
+	}
+	if response["status"] != float64(http.StatusOK) || response["level"] != "INFO" {
+		t.Errorf("status = %v at level %v, want 200 at INFO", response["status"], response["level"])
+	}
+}
+
+func TestLoggingInterceptorInfoLevel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":"INVALID_AMOUNT","message":"bad amount"}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	client := NewClient(newTestConfig(t, srv.URL, WithLogger(logger)))
+
+	err := client.DoRequest(context.Background(), Request{
+		Method: http.MethodPost,
+		Path:   "/collection/v1_0/requesttopay",
+		Body:   map[string]string{"amount": "100"},
+	}, nil)
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("errors.Is(%v, ErrInvalidRequest) = false", err)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("got %d log records, want only the response:\n%s", len(records), buf.String())
+	}
+	if _, ok := records[0]["body"]; ok {
+		t.Error("body logged without debug level")
+	}
+	if records[0]["status"] != float64(http.StatusBadRequest) || records[0]["level"] != "WARN" {
+		t.Errorf("status = %v at level %v, want 400 at WARN", records[0]["status"], records[0]["level"])
+	}
+}
+
+// failingTransport fails every request with an error that embeds the URL
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestLoggingInterceptorTransportError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	client := NewClient(newTestConfig(t, "http://momo.invalid",
+		WithLogger(logger),
+		WithTransport(failingTransport{}),
+	))
+
+	err := client.DoRequest(context.Background(), Request{
+		Method: http.MethodGet,
+		Path:   "/collection/v1_0/accountholder/msisdn/46733123454/active",
+	}, nil)
+	if err == nil {
+		t.Fatal("DoRequest succeeded, want a transport error")
+	}
+	if !strings.Contains(err.Error(), "46733123454") {
+		t.Fatalf("error %q does not carry the URL, test is not exercising the rewrite", err)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "momo request failed" {
+		t.Fatalf("log records = %v, want one failed request", records)
+	}
+	message, _ := records[0]["error"].(string)
+	if strings.Contains(message, "46733123454") {
+		t.Errorf("logged error %q contains the MSISDN", message)
+	}
+	if !strings.Contains(message, "/collection/v1_0/accountholder/msisdn/{accountHolderId}/active") {
+		t.Errorf("logged error %q does not contain the templated path", message)
+	}
+}
@@ -1,23 +1,32 @@
 package gomomo
 
-// MoMoClient is the main client for interacting with MTN MoMo API
+// MoMoClient is the main client for interacting with MTN MoMo API. Its
+// services are interfaces, so tests can swap in fakes such as the mocks in
+// the gomomomock package.
 type MoMoClient struct {
 	Config       *Config
-	Auth         *AuthService
-	Collection   *CollectionService
-	Disbursement *DisbursementService
+	Auth         Authenticator
+	Collection   Collector
+	Disbursement Disburser
+	Remittance   Remitter
 }
 
 // NewMoMoClient creates a new MTN MoMo client
 func NewMoMoClient(config *Config) *MoMoClient {
 	client := NewClient(config)
 	authService := NewAuthService(client, config)
+	collection := NewCollectionService(client, config, authService)
+
+	// Refunds are checked against the client's own collection service
+	disbursement := NewDisbursementService(client, config, authService)
+	disbursement.collection = collection
 
 	return &MoMoClient{
 		Config:       config,
 		Auth:         authService,
-		Collection:   NewCollectionService(client, config, authService),
-		Disbursement: NewDisbursementService(client, config, authService),
+		Collection:   collection,
+		Disbursement: disbursement,
+		Remittance:   NewRemittanceService(client, config, authService),
 	}
 }
 
@@ -1,6 +1,9 @@
 package gomomo
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -17,12 +20,46 @@ const (
 	Party PartyIDType = "PARTY_CODE"
 )
 
+// APIVersion selects the version of an endpoint that exists in several versions
+type APIVersion string
+
+const (
+	// V1 is the v1_0 version of an endpoint
+	V1 APIVersion = "v1_0"
+	// V2 is the v2_0 version of an endpoint
+	V2 APIVersion = "v2_0"
+)
+
+// versionedPath fills the version into an endpoint path template, e.g.
+// "/disbursement/%s/deposit", defaulting to V1
+func versionedPath(template string, version APIVersion) (string, error) {
+	switch version {
+	case "":
+		version = V1
+	case V1, V2:
+	default:
+		return "", fmt.Errorf("unsupported API version: %s", version)
+	}
+	return fmt.Sprintf(template, version), nil
+}
+
 // PartyInfo represents a payer or payee in a transaction
 type PartyInfo struct {
 	PartyIDType PartyIDType `json:"partyIdType"`
 	PartyID     string      `json:"partyId"`
 }
 
+// accountHolderPath builds the path of an account holder resource, e.g.
+// /collection/v1_0/accountholder/msisdn/256772123456/active
+func accountHolderPath(product string, party PartyInfo, resource string) string {
+	return fmt.Sprintf("/%s/v1_0/accountholder/%s/%s", product, partyPath(party), resource)
+}
+
+// partyPath formats a party as path segments, e.g. msisdn/256772123456
+func partyPath(party PartyInfo) string {
+	return strings.ToLower(string(party.PartyIDType)) + "/" + url.PathEscape(party.PartyID)
+}
+
 // RequestToPayPayload represents the payload for a collection request
 type RequestToPayPayload struct {
 	Amount       string    `json:"amount"`
@@ -43,6 +80,43 @@ type TransferPayload struct {
 	PayeeNote    string    `json:"payeeNote"`
 }
 
+// RefundPayload represents the payload for a disbursement refund
+type RefundPayload struct {
+	Amount              string `json:"amount"`
+	Currency            string `json:"currency"`
+	ExternalID          string `json:"externalId"`
+	PayerMessage        string `json:"payerMessage"`
+	PayeeNote           string `json:"payeeNote"`
+	ReferenceIDToRefund string `json:"referenceIdToRefund"`
+}
+
+// CashTransferPayer identifies the sender of a cash transfer
+type CashTransferPayer struct {
+	IdentificationType   string `json:"payerIdentificationType,omitempty"`
+	IdentificationNumber string `json:"payerIdentificationNumber,omitempty"`
+	Identity             string `json:"payerIdentity,omitempty"`
+	FirstName            string `json:"payerFirstName,omitempty"`
+	SurName              string `json:"payerSurName,omitempty"`
+	LanguageCode         string `json:"payerLanguageCode,omitempty"`
+	Email                string `json:"payerEmail,omitempty"`
+	MSISDN               string `json:"payerMsisdn,omitempty"`
+	Gender               string `json:"payerGender,omitempty"`
+}
+
+// CashTransferPayload represents the payload for a remittance cash transfer
+type CashTransferPayload struct {
+	Amount             string    `json:"amount"`
+	Currency           string    `json:"currency"`
+	ExternalID         string    `json:"externalId"`
+	Payee              PartyInfo `json:"payee"`
+	OriginatingCountry string    `json:"originatingCountry,omitempty"`
+	OriginalAmount     string    `json:"originalAmount,omitempty"`
+	OriginalCurrency   string    `json:"originalCurrency,omitempty"`
+	PayerMessage       string    `json:"payerMessage"`
+	PayeeNote          string    `json:"payeeNote"`
+	CashTransferPayer
+}
+
 // TransactionStatus represents the status of a transaction
 type TransactionStatus string
 
@@ -73,6 +147,62 @@ type TransactionStatusResponse struct {
 	FinancialTransactionID string            `json:"financialTransactionId,omitempty"`
 }
 
+// Money returns the transaction amount as an exact Money value
+func (r *TransactionStatusResponse) Money() (Money, error) {
+	return ParseMoney(r.Amount, r.Currency)
+}
+
+// CashTransferStatusResponse represents the response from a cash transfer status check
+type CashTransferStatusResponse struct {
+	TransactionStatusResponse
+	OriginatingCountry string `json:"originatingCountry,omitempty"`
+	OriginalAmount     string `json:"originalAmount,omitempty"`
+	OriginalCurrency   string `json:"originalCurrency,omitempty"`
+	CashTransferPayer
+}
+
+// Money returns the requested amount as an exact Money value
+func (p *RequestToPayPayload) Money() (Money, error) {
+	return ParseMoney(p.Amount, p.Currency)
+}
+
+// Money returns the transferred amount as an exact Money value
+func (p *TransferPayload) Money() (Money, error) {
+	return ParseMoney(p.Amount, p.Currency)
+}
+
+// Balance represents the response from an account balance request. The raw
+// AvailableBalance and Currency fields are authoritative; Available holds
+// the same amount as exact Money when it could be parsed.
+type Balance struct {
+	AvailableBalance string `json:"availableBalance"`
+	Currency         string `json:"currency"`
+	Available        Money  `json:"-"` // Available balance as an exact amount (zero if unparseable)
+}
+
+// Money returns the available balance as an exact Money value
+func (b *Balance) Money() (Money, error) {
+	return ParseMoney(b.AvailableBalance, b.Currency)
+}
+
+// UnmarshalJSON decodes a balance response. The available amount is parsed
+// on a best-effort basis, so an unexpected format does not fail the request;
+// call Money to get the parse error.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AvailableBalance string `json:"availableBalance"`
+		Currency         string `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	b.AvailableBalance = raw.AvailableBalance
+	b.Currency = raw.Currency
+	b.Available, _ = b.Money()
+	return nil
+}
+
 // AccountHolderInfo represents basic information about an account holder
 type AccountHolderInfo struct {
 	GivenName  string `json:"given_name"`
@@ -0,0 +1,39 @@
+package momotest
+
+import "github.com/sir-george2500/gomomo"
+
+// SandboxNumbers returns the table of test MSISDNs with special outcomes.
+// The 4673312345x numbers behave as in MTN's sandbox: failed, rejected,
+// timed out, never leaving PENDING, and successful after a couple of pending
+// polls. The 99999900000x numbers are momotest's own and do not exist in
+// MTN's sandbox; each fails with one of the reason codes a payment can fail
+// with. The returned map is a fresh copy, so callers can add entries before
+// passing it to WithOutcomes.
+func SandboxNumbers() map[string]Outcome {
+	return map[string]Outcome{
+		// MTN sandbox numbers
+		"46733123450": {Status: gomomo.Failed, Reason: ReasonInternalProcessingError},
+		"46733123451": {Status: gomomo.Rejected, Reason: ReasonApprovalRejected},
+		"46733123452": {Status: gomomo.Timeout, Reason: ReasonExpired},
+		"46733123453": {Status: gomomo.Pending},
+		"46733123454": {PendingPolls: 2},
+
+		// momotest-only numbers
+		"999999000001": {Status: gomomo.Failed, Reason: ReasonPayerNotFound},
+		"999999000002": {Status: gomomo.Failed, Reason: ReasonPayeeNotFound},
+		"999999000003": {Status: gomomo.Failed, Reason: ReasonNotEnoughFunds},
+		"999999000004": {Status: gomomo.Failed, Reason: ReasonPayerLimitReached},
+		"999999000005": {Status: gomomo.Failed, Reason: ReasonNotAllowed},
+		"999999000006": {Status: gomomo.Failed, Reason: ReasonServiceUnavailable},
+	}
+}
+
+// WithOutcomes scripts the outcomes of several MSISDNs at once, e.g.
+// WithOutcomes(SandboxNumbers()). Later calls to Script take precedence.
+func WithOutcomes(outcomes map[string]Outcome) Option {
+	return func(s *Server) {
+		for msisdn, outcome := range outcomes {
+			s.outcomes[msisdn] = outcome
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package momotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+func TestSandboxNumbers(t *testing.T) {
+	tests := []struct {
+		msisdn     string
+		wantPolls  []gomomo.TransactionStatus
+		wantReason string
+	}{
+		{"46733123450", []gomomo.TransactionStatus{gomomo.Failed}, ReasonInternalProcessingError},
+		{"46733123451", []gomomo.TransactionStatus{gomomo.Rejected}, ReasonApprovalRejected},
+		{"46733123452", []gomomo.TransactionStatus{gomomo.Timeout}, ReasonExpired},
+		{"46733123453", []gomomo.TransactionStatus{gomomo.Pending, gomomo.Pending, gomomo.Pending, gomomo.Pending}, ""},
+		{"46733123454", []gomomo.TransactionStatus{gomomo.Pending, gomomo.Pending, gomomo.Successful}, ""},
+		{"999999000001", []gomomo.TransactionStatus{gomomo.Failed}, ReasonPayerNotFound},
+		{"999999000002", []gomomo.TransactionStatus{gomomo.Failed}, ReasonPayeeNotFound},
+		{"999999000003", []gomomo.TransactionStatus{gomomo.Failed}, ReasonNotEnoughFunds},
+		{"999999000004", []gomomo.TransactionStatus{gomomo.Failed}, ReasonPayerLimitReached},
+		{"999999000005", []gomomo.TransactionStatus{gomomo.Failed}, ReasonNotAllowed},
+		{"999999000006", []gomomo.TransactionStatus{gomomo.Failed}, ReasonServiceUnavailable},
+	}
+
+	if len(tests) != len(SandboxNumbers()) {
+		t.Fatalf("testing %d numbers, SandboxNumbers has %d", len(tests), len(SandboxNumbers()))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msisdn, func(t *testing.T) {
+			srv := NewServer(WithOutcomes(SandboxNumbers()))
+			defer srv.Close()
+			client, err := srv.Client()
+			if err != nil {
+				t.Fatalf("Client: %v", err)
+			}
+
+			ctx := context.Background()
+			referenceID, err := client.Collection.RequestToPayMoney(ctx, tt.msisdn, gomomo.NewMoney(500, "EUR"), nil)
+			if err != nil {
+				t.Fatalf("RequestToPayMoney: %v", err)
+			}
+
+			var status *gomomo.TransactionStatusResponse
+			for i, want := range tt.wantPolls {
+				status, err = client.Collection.GetTransactionStatus(ctx, referenceID)
+				if err != nil {
+					t.Fatalf("poll %d: %v", i+1, err)
+				}
+				if status.Status != want {
+					t.Fatalf("poll %d status = %s, want %s", i+1, status.Status, want)
+				}
+			}
+			if status.Reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", status.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestScriptedNumberWithoutWallet(t *testing.T) {
+	srv := NewServer(
+		WithOutcomes(SandboxNumbers()),
+		WithAccountBalance("disbursement", gomomo.NewMoney(1000, "EUR")),
+	)
+	defer srv.Close()
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		amount      int64
+		wantStatus  gomomo.TransactionStatus
+		wantReason  string
+		wantAccount int64
+	}{
+		{"covered by the account", 600, gomomo.Successful, "", 400},
+		{"exceeds the account", 600, gomomo.Failed, ReasonNotEnoughFunds, 400},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		referenceID, err := client.Disbursement.TransferMoney(ctx, "46733123454", gomomo.NewMoney(tt.amount, "EUR"), nil)
+		if err != nil {
+			t.Fatalf("%s: TransferMoney: %v", tt.name, err)
+		}
+		status, err := client.Disbursement.WaitForFinalStatus(ctx, referenceID, &gomomo.WaitOptions{InitialInterval: 1, MaxInterval: 1})
+		if status == nil || status.Status != tt.wantStatus || status.Reason != tt.wantReason {
+			t.Errorf("%s: status = %+v, %v; want %s/%q", tt.name, status, err, tt.wantStatus, tt.wantReason)
+		}
+		if got := srv.AccountBalance("disbursement", "EUR").Amount; got != tt.wantAccount {
+			t.Errorf("%s: account balance = %d, want %d", tt.name, got, tt.wantAccount)
+		}
+	}
+	if _, ok := srv.Wallet("46733123454"); ok {
+		t.Error("a wallet was created for the scripted number")
+	}
+}
@@ -0,0 +1,370 @@
+// Package momotest provides an in-process fake of the MTN MoMo API for
+// tests. It implements API user provisioning, product tokens, request-to-pay,
+// transfers, status checks, balances and account holder lookups on top of
+// in-memory wallets, and enforces the same headers as the real API.
+package momotest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sir-george2500/gomomo"
+)
+
+// Products served by the fake API
+var products = []string{"collection", "disbursement", "remittance"}
+
+// Server is a fake MTN MoMo API running on a local httptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu                sync.Mutex
+	targetEnvironment string
+	currency          string
+	subscriptionKeys  map[string]string                  // product -> key
+	apiUsers          map[string]string                  // API user -> API key ("" until created)
+	tokens            map[string]string                  // access token -> product
+	accounts          map[string]map[string]gomomo.Money // product -> currency -> balance
+	wallets           map[string]*Wallet                 // MSISDN -> wallet
+	outcomes          map[string]Outcome                 // MSISDN -> scripted outcome
+	transactions      map[string]*Transaction            // reference ID -> transaction
+	settled           int                                // successful transactions, for financial IDs
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithSubscriptionKey sets the subscription key accepted for a product
+func WithSubscriptionKey(product, key string) Option {
+	return func(s *Server) {
+		s.subscriptionKeys[product] = key
+	}
+}
+
+// WithTargetEnvironment sets the X-Target-Environment accepted by the server
+func WithTargetEnvironment(env string) Option {
+	return func(s *Server) {
+		s.targetEnvironment = env
+	}
+}
+
+// WithCurrency sets the default currency of the merchant accounts
+func WithCurrency(currency string) Option {
+	return func(s *Server) {
+		s.currency = currency
+	}
+}
+
+// WithAccountBalance sets the balance of a product's merchant account
+func WithAccountBalance(product string, balance gomomo.Money) Option {
+	return func(s *Server) {
+		s.setAccountBalance(product, balance)
+	}
+}
+
+// WithAPIUser registers an existing API user and key, as in production
+func WithAPIUser(apiUser, apiKey string) Option {
+	return func(s *Server) {
+		s.apiUsers[apiUser] = apiKey
+	}
+}
+
+// NewServer starts a fake MTN MoMo API. By default it accepts the
+// subscription key "test-key" for every product, the "sandbox" target
+// environment and EUR, and merchant accounts start empty.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		targetEnvironment: "sandbox",
+		currency:          "EUR",
+		subscriptionKeys:  make(map[string]string),
+		apiUsers:          make(map[string]string),
+		tokens:            make(map[string]string),
+		accounts:          make(map[string]map[string]gomomo.Money),
+		wallets:           make(map[string]*Wallet),
+		outcomes:          make(map[string]Outcome),
+		transactions:      make(map[string]*Transaction),
+	}
+	for _, product := range products {
+		s.subscriptionKeys[product] = "test-key"
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Config returns a sandbox configuration pointing at the server, with the
+// server's subscription keys, target environment and currency. Retries are
+// disabled so that scripted failures surface immediately; further options
+// are applied last.
+func (s *Server) Config(opts ...gomomo.ConfigOption) (*gomomo.Config, error) {
+	s.mu.Lock()
+	base := []gomomo.ConfigOption{
+		gomomo.WithSubscriptionKey(s.subscriptionKeys["collection"]),
+		gomomo.WithDisbursementKey(s.subscriptionKeys["disbursement"]),
+		gomomo.WithRemittanceKey(s.subscriptionKeys["remittance"]),
+		gomomo.WithTargetEnvironment(s.targetEnvironment),
+		gomomo.WithCurrency(s.currency),
+		gomomo.WithBaseURL(s.URL),
+		gomomo.WithRetryPolicy(gomomo.NoRetryPolicy()),
+	}
+	s.mu.Unlock()
+
+	return gomomo.NewConfig(gomomo.Sandbox, append(base, opts...)...)
+}
+
+// Client returns a MoMo client talking to the server
+func (s *Server) Client(opts ...gomomo.ConfigOption) (*gomomo.MoMoClient, error) {
+	config, err := s.Config(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gomomo.NewMoMoClient(config), nil
+}
+
+// SetAccountBalance sets the balance of a product's merchant account
+func (s *Server) SetAccountBalance(product string, balance gomomo.Money) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setAccountBalance(product, balance)
+}
+
+// AccountBalance returns the balance of a product's merchant account
+func (s *Server) AccountBalance(product, currency string) gomomo.Money {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accountBalance(product, strings.ToUpper(currency))
+}
+
+// Transaction returns a copy of the transaction with the given reference ID
+func (s *Server) Transaction(referenceID string) (Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[referenceID]
+	if !ok {
+		return Transaction{}, false
+	}
+	return *tx, true
+}
+
+// Transactions returns copies of all transactions received by the server
+func (s *Server) Transactions() []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Transaction, 0, len(s.transactions))
+	for _, tx := range s.transactions {
+		result = append(result, *tx)
+	}
+	return result
+}
+
+func (s *Server) setAccountBalance(product string, balance gomomo.Money) {
+	if s.accounts[product] == nil {
+		s.accounts[product] = make(map[string]gomomo.Money)
+	}
+	s.accounts[product][balance.Currency] = balance
+}
+
+func (s *Server) accountBalance(product, currency string) gomomo.Money {
+	if balance, ok := s.accounts[product][currency]; ok {
+		return balance
+	}
+	return gomomo.NewMoney(0, currency)
+}
+
+// routes registers the API endpoints
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	// Provisioning
+	mux.HandleFunc("POST /v1_0/apiuser", s.handleCreateAPIUser)
+	mux.HandleFunc("POST /v1_0/apiuser/{apiUser}/apikey", s.handleCreateAPIKey)
+
+	for _, product := range products {
+		mux.HandleFunc("POST /"+product+"/token/", s.handleToken(product))
+		mux.HandleFunc("GET /"+product+"/v1_0/account/balance", s.authorized(product, s.handleBalance(product)))
+		mux.HandleFunc("GET /"+product+"/v1_0/account/balance/{currency}", s.authorized(product, s.handleBalance(product)))
+		mux.HandleFunc("GET /"+product+"/v1_0/accountholder/{type}/{id}/basicuserinfo", s.authorized(product, s.handleBasicUserInfo))
+		mux.HandleFunc("GET /"+product+"/v1_0/accountholder/{type}/{id}/active", s.authorized(product, s.handleActive))
+	}
+
+	// Payments
+	mux.HandleFunc("POST /collection/v1_0/requesttopay", s.authorized("collection", s.handleCreate("collection", "requesttopay")))
+	mux.HandleFunc("GET /collection/v1_0/requesttopay/{referenceId}", s.authorized("collection", s.handleStatus("collection", "requesttopay")))
+	mux.HandleFunc("POST /disbursement/v1_0/transfer", s.authorized("disbursement", s.handleCreate("disbursement", "transfer")))
+	mux.HandleFunc("GET /disbursement/v1_0/transfer/{referenceId}", s.authorized("disbursement", s.handleStatus("disbursement", "transfer")))
+	mux.HandleFunc("POST /remittance/v1_0/transfer", s.authorized("remittance", s.handleCreate("remittance", "transfer")))
+	mux.HandleFunc("GET /remittance/v1_0/transfer/{referenceId}", s.authorized("remittance", s.handleStatus("remittance", "transfer")))
+
+	return mux
+}
+
+// handleCreateAPIUser provisions a sandbox API user
+func (s *Server) handleCreateAPIUser(w http.ResponseWriter, r *http.Request) {
+	if !s.validSubscriptionKey(r, "") {
+		writeSubscriptionKeyError(w)
+		return
+	}
+
+	referenceID := r.Header.Get("X-Reference-Id")
+	if _, err := uuid.Parse(referenceID); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REFERENCE_ID", "X-Reference-Id must be a UUID")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.apiUsers[referenceID]; ok {
+		writeError(w, http.StatusConflict, "RESOURCE_ALREADY_EXIST", "Duplicated reference id. Creation of resource failed.")
+		return
+	}
+	s.apiUsers[referenceID] = ""
+	w.WriteHeader(http.StatusCreated)
+}
+
+// handleCreateAPIKey issues a key for a sandbox API user
+func (s *Server) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !s.validSubscriptionKey(r, "") {
+		writeSubscriptionKeyError(w)
+		return
+	}
+
+	apiUser := r.PathValue("apiUser")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.apiUsers[apiUser]; !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Requested resource was not found.")
+		return
+	}
+	apiKey := strings.ReplaceAll(uuid.New().String(), "-", "")
+	s.apiUsers[apiUser] = apiKey
+
+	writeJSON(w, http.StatusCreated, map[string]string{"apiKey": apiKey})
+}
+
+// handleToken issues an access token for a product
+func (s *Server) handleToken(product string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.validSubscriptionKey(r, product) {
+			writeSubscriptionKeyError(w)
+			return
+		}
+
+		apiUser, apiKey, ok := basicAuth(r)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if expected, known := s.apiUsers[apiUser]; !ok || !known || expected == "" || expected != apiKey {
+			writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid API user or key.")
+			return
+		}
+
+		token := uuid.New().String()
+		s.tokens[token] = product
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": token,
+			"token_type":   "access_token",
+			"expires_in":   3600,
+		})
+	}
+}
+
+// authorized checks the subscription key, bearer token and target environment
+func (s *Server) authorized(product string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.validSubscriptionKey(r, product) {
+			writeSubscriptionKeyError(w)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		s.mu.Lock()
+		tokenProduct, known := s.tokens[token]
+		targetEnvironment := s.targetEnvironment
+		s.mu.Unlock()
+
+		if !ok || !known || tokenProduct != product {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authorization failed. Insufficient permissions.")
+			return
+		}
+		if r.Header.Get("X-Target-Environment") != targetEnvironment {
+			writeError(w, http.StatusBadRequest, "INVALID_TARGET_ENVIRONMENT", "Unknown X-Target-Environment.")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// validSubscriptionKey checks the subscription key of a request. Provisioning
+// requests (empty product) accept the key of any product.
+func (s *Server) validSubscriptionKey(r *http.Request, product string) bool {
+	key := r.Header.Get("Ocp-Apim-Subscription-Key")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if product != "" {
+		return key != "" && key == s.subscriptionKeys[product]
+	}
+	for _, expected := range s.subscriptionKeys {
+		if key != "" && key == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// basicAuth decodes the Basic authorization header
+func basicAuth(r *http.Request) (string, string, bool) {
+	encoded, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Basic ")
+	if !ok {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in MTN's {code, message} format
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{
+		"code":    code,
+		"message": message,
+	})
+}
+
+// writeSubscriptionKeyError writes the API gateway's invalid key response
+func writeSubscriptionKeyError(w http.ResponseWriter) {
+	writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+		"statusCode": http.StatusUnauthorized,
+		"message":    "Access denied due to invalid subscription key. Make sure to provide a valid key for an active subscription.",
+	})
+}
@@ -0,0 +1,333 @@
+package momotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+const (
+	funded   = "256772123456"
+	unfunded = "256772123457"
+	inactive = "256772123458"
+	unknown  = "256772123459"
+)
+
+// newTestServer starts a server with a funded, an empty and an inactive
+// wallet and money in the disbursement account
+func newTestServer(t *testing.T, opts ...Option) (*Server, *gomomo.MoMoClient) {
+	t.Helper()
+
+	srv := NewServer(append([]Option{
+		WithAccountBalance("disbursement", gomomo.NewMoney(10000, "EUR")),
+	}, opts...)...)
+	t.Cleanup(srv.Close)
+
+	srv.AddWallet(Wallet{MSISDN: funded, Balance: gomomo.NewMoney(5000, "EUR")})
+	srv.AddWallet(Wallet{MSISDN: unfunded})
+	srv.AddWallet(Wallet{MSISDN: inactive, Balance: gomomo.NewMoney(5000, "EUR"), Inactive: true})
+
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	return srv, client
+}
+
+func TestRequestToPay(t *testing.T) {
+	tests := []struct {
+		name        string
+		msisdn      string
+		amount      int64
+		outcome     *Outcome
+		wantStatus  gomomo.TransactionStatus
+		wantReason  string
+		wantWallet  int64
+		wantAccount int64
+	}{
+		{"success", funded, 1000, nil, gomomo.Successful, "", 4000, 1000},
+		{"whole balance", funded, 5000, nil, gomomo.Successful, "", 0, 5000},
+		{"not enough funds", unfunded, 1000, nil, gomomo.Failed, ReasonNotEnoughFunds, 0, 0},
+		{"inactive wallet", inactive, 1000, nil, gomomo.Failed, ReasonNotAllowed, 5000, 0},
+		{"unknown payer", unknown, 1000, nil, gomomo.Failed, ReasonPayerNotFound, -1, 0},
+		{"scripted rejection", funded, 1000, &Outcome{Status: gomomo.Rejected, Reason: ReasonApprovalRejected}, gomomo.Rejected, ReasonApprovalRejected, 5000, 0},
+		{"scripted timeout", funded, 1000, &Outcome{Status: gomomo.Timeout, Reason: ReasonExpired}, gomomo.Timeout, ReasonExpired, 5000, 0},
+		{"scripted pending", funded, 1000, &Outcome{Status: gomomo.Pending}, gomomo.Pending, "", 5000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client := newTestServer(t)
+			if tt.outcome != nil {
+				srv.Script(tt.msisdn, *tt.outcome)
+			}
+
+			ctx := context.Background()
+			referenceID, err := client.Collection.RequestToPayMoney(ctx, tt.msisdn, gomomo.NewMoney(tt.amount, "EUR"), &gomomo.RequestToPayOptions{ExternalID: "order-1"})
+			if err != nil {
+				t.Fatalf("RequestToPayMoney: %v", err)
+			}
+
+			status, err := client.Collection.GetTransactionStatus(ctx, referenceID)
+			if err != nil {
+				t.Fatalf("GetTransactionStatus: %v", err)
+			}
+			if status.Status != tt.wantStatus || status.Reason != tt.wantReason {
+				t.Errorf("status = %s/%q, want %s/%q", status.Status, status.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if status.Payer.PartyID != tt.msisdn || status.ExternalID != "order-1" {
+				t.Errorf("status payer %q, external ID %q", status.Payer.PartyID, status.ExternalID)
+			}
+			if (status.FinancialTransactionID != "") != (tt.wantStatus == gomomo.Successful) {
+				t.Errorf("financial transaction ID = %q for %s", status.FinancialTransactionID, status.Status)
+			}
+
+			if wallet, ok := srv.Wallet(tt.msisdn); ok && wallet.Balance.Amount != tt.wantWallet {
+				t.Errorf("wallet balance = %d, want %d", wallet.Balance.Amount, tt.wantWallet)
+			}
+			if got := srv.AccountBalance("collection", "EUR").Amount; got != tt.wantAccount {
+				t.Errorf("account balance = %d, want %d", got, tt.wantAccount)
+			}
+
+			tx, ok := srv.Transaction(referenceID)
+			if !ok || tx.Product != "collection" || tx.Kind != "requesttopay" || tx.Polls != 1 {
+				t.Errorf("Transaction(%s) = %+v, %v", referenceID, tx, ok)
+			}
+		})
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name        string
+		msisdn      string
+		amount      int64
+		wantStatus  gomomo.TransactionStatus
+		wantReason  string
+		wantWallet  int64
+		wantAccount int64
+	}{
+		{"success", unfunded, 1000, gomomo.Successful, "", 1000, 9000},
+		{"not enough funds", funded, 20000, gomomo.Failed, ReasonNotEnoughFunds, 5000, 10000},
+		{"inactive wallet", inactive, 1000, gomomo.Failed, ReasonNotAllowed, 5000, 10000},
+		{"unknown payee", unknown, 1000, gomomo.Failed, ReasonPayeeNotFound, -1, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client := newTestServer(t)
+
+			ctx := context.Background()
+			referenceID, err := client.Disbursement.TransferMoney(ctx, tt.msisdn, gomomo.NewMoney(tt.amount, "EUR"), nil)
+			if err != nil {
+				t.Fatalf("TransferMoney: %v", err)
+			}
+
+			status, err := client.Disbursement.GetTransferStatus(ctx, referenceID)
+			if err != nil {
+				t.Fatalf("GetTransferStatus: %v", err)
+			}
+			if status.Status != tt.wantStatus || status.Reason != tt.wantReason {
+				t.Errorf("status = %s/%q, want %s/%q", status.Status, status.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if status.Payee.PartyID != tt.msisdn {
+				t.Errorf("status payee = %q, want %q", status.Payee.PartyID, tt.msisdn)
+			}
+
+			if wallet, ok := srv.Wallet(tt.msisdn); ok && wallet.Balance.Amount != tt.wantWallet {
+				t.Errorf("wallet balance = %d, want %d", wallet.Balance.Amount, tt.wantWallet)
+			}
+			if got := srv.AccountBalance("disbursement", "EUR").Amount; got != tt.wantAccount {
+				t.Errorf("account balance = %d, want %d", got, tt.wantAccount)
+			}
+		})
+	}
+}
+
+func TestPendingPolls(t *testing.T) {
+	srv, client := newTestServer(t)
+	srv.Script(funded, Outcome{PendingPolls: 2})
+
+	ctx := context.Background()
+	referenceID, err := client.Collection.RequestToPayMoney(ctx, funded, gomomo.NewMoney(1000, "EUR"), nil)
+	if err != nil {
+		t.Fatalf("RequestToPayMoney: %v", err)
+	}
+
+	want := []gomomo.TransactionStatus{gomomo.Pending, gomomo.Pending, gomomo.Successful, gomomo.Successful}
+	for i, wantStatus := range want {
+		status, err := client.Collection.GetTransactionStatus(ctx, referenceID)
+		if err != nil {
+			t.Fatalf("poll %d: %v", i+1, err)
+		}
+		if status.Status != wantStatus {
+			t.Errorf("poll %d status = %s, want %s", i+1, status.Status, wantStatus)
+		}
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("duplicate reference ID", func(t *testing.T) {
+		_, client := newTestServer(t)
+		opts := &gomomo.RequestToPayOptions{ReferenceID: "6f1c3c2e-8d0a-4b8e-9f43-2d1c6b0e7a11"}
+		if _, err := client.Collection.RequestToPayMoney(ctx, funded, gomomo.NewMoney(100, "EUR"), opts); err != nil {
+			t.Fatalf("first request: %v", err)
+		}
+		_, err := client.Collection.RequestToPayMoney(ctx, funded, gomomo.NewMoney(100, "EUR"), opts)
+		if !errors.Is(err, gomomo.ErrDuplicateReferenceID) {
+			t.Errorf("second request error = %v, want ErrDuplicateReferenceID", err)
+		}
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		_, client := newTestServer(t)
+		_, err := client.Collection.RequestToPayMoney(ctx, funded, gomomo.NewMoney(100, "UGX"), nil)
+		var momoErr *gomomo.MoMoError
+		if !errors.As(err, &momoErr) || momoErr.Code != "INVALID_CURRENCY" {
+			t.Errorf("error = %v, want INVALID_CURRENCY", err)
+		}
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		_, client := newTestServer(t)
+		_, err := client.Collection.GetTransactionStatus(ctx, "6f1c3c2e-8d0a-4b8e-9f43-2d1c6b0e7a11")
+		if !errors.Is(err, gomomo.ErrNotFound) {
+			t.Errorf("error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	srv, client := newTestServer(t)
+
+	ctx := context.Background()
+	collectionToken, err := client.Auth.GetAccessToken(ctx, "collection")
+	if err != nil {
+		t.Fatalf("GetAccessToken: %v", err)
+	}
+	disbursementToken, err := client.Auth.GetAccessToken(ctx, "disbursement")
+	if err != nil {
+		t.Fatalf("GetAccessToken: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		token      string
+		env        string
+		wantStatus int
+	}{
+		{"authorized", "test-key", collectionToken, "sandbox", http.StatusOK},
+		{"wrong subscription key", "other-key", collectionToken, "sandbox", http.StatusUnauthorized},
+		{"missing token", "test-key", "", "sandbox", http.StatusUnauthorized},
+		{"unknown token", "test-key", "not-a-token", "sandbox", http.StatusUnauthorized},
+		{"token for another product", "test-key", disbursementToken, "sandbox", http.StatusUnauthorized},
+		{"wrong target environment", "test-key", collectionToken, "mtnuganda", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+"/collection/v1_0/account/balance", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Ocp-Apim-Subscription-Key", tt.key)
+			req.Header.Set("X-Target-Environment", tt.env)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestProvisionedCredentials(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		opts    []gomomo.ConfigOption
+		wantErr error
+	}{
+		{"provisioned on demand", nil, nil},
+		{"unknown API user", []gomomo.ConfigOption{gomomo.WithAPIUser("user"), gomomo.WithAPIKey("key")}, gomomo.ErrAuthenticationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := srv.Client(tt.opts...)
+			if err != nil {
+				t.Fatalf("Client: %v", err)
+			}
+			_, err = client.Auth.GetAccessToken(context.Background(), "collection")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("GetAccessToken: %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetAccessToken error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("registered API user", func(t *testing.T) {
+		srv, _ := newTestServer(t, WithAPIUser("0b6a5c4f-2e8d-4b1a-9c3e-7f5d1a2b3c4d", "secret"))
+		client, err := srv.Client(gomomo.WithAPIUser("0b6a5c4f-2e8d-4b1a-9c3e-7f5d1a2b3c4d"), gomomo.WithAPIKey("secret"))
+		if err != nil {
+			t.Fatalf("Client: %v", err)
+		}
+		if _, err := client.Auth.GetAccessToken(context.Background(), "disbursement"); err != nil {
+			t.Errorf("GetAccessToken: %v", err)
+		}
+	})
+}
+
+func TestBalancesAndAccountHolders(t *testing.T) {
+	srv, client := newTestServer(t)
+	srv.SetAccountBalance("remittance", gomomo.NewMoney(2500, "USD"))
+	ctx := context.Background()
+
+	balance, err := client.Disbursement.GetBalance(ctx)
+	if err != nil || balance.Available != gomomo.NewMoney(10000, "EUR") {
+		t.Errorf("disbursement balance = %+v, %v", balance, err)
+	}
+	balance, err = client.Remittance.GetAccountBalanceInCurrency(ctx, "USD")
+	if err != nil || balance.Available != gomomo.NewMoney(2500, "USD") {
+		t.Errorf("remittance USD balance = %+v, %v", balance, err)
+	}
+
+	tests := []struct {
+		msisdn     string
+		wantActive bool
+	}{
+		{funded, true},
+		{inactive, false},
+		{unknown, false},
+	}
+	for _, tt := range tests {
+		active, err := client.Collection.IsAccountHolderActive(ctx, gomomo.PartyInfo{PartyIDType: gomomo.MSISDN, PartyID: tt.msisdn})
+		if err != nil || active != tt.wantActive {
+			t.Errorf("IsAccountHolderActive(%s) = %v, %v; want %v", tt.msisdn, active, err, tt.wantActive)
+		}
+	}
+
+	info, err := client.Collection.GetAccountHolderInfo(ctx, funded)
+	if err != nil || info.Status != "ACTIVE" {
+		t.Errorf("GetAccountHolderInfo = %+v, %v", info, err)
+	}
+	if _, err := client.Collection.GetAccountHolderInfo(ctx, unknown); !errors.Is(err, gomomo.ErrNotFound) {
+		t.Errorf("GetAccountHolderInfo(unknown) error = %v, want ErrNotFound", err)
+	}
+}
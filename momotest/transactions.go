@@ -0,0 +1,241 @@
+package momotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sir-george2500/gomomo"
+)
+
+// Reason codes reported for unsuccessful transactions
+const (
+	ReasonPayerNotFound           = "PAYER_NOT_FOUND"
+	ReasonPayeeNotFound           = "PAYEE_NOT_FOUND"
+	ReasonNotEnoughFunds          = "NOT_ENOUGH_FUNDS"
+	ReasonNotAllowed              = "NOT_ALLOWED"
+	ReasonPayerLimitReached       = "PAYER_LIMIT_REACHED"
+	ReasonApprovalRejected        = "APPROVAL_REJECTED"
+	ReasonExpired                 = "EXPIRED"
+	ReasonInternalProcessingError = "INTERNAL_PROCESSING_ERROR"
+	ReasonServiceUnavailable      = "SERVICE_UNAVAILABLE"
+)
+
+// Outcome scripts how transactions for a party resolve
+type Outcome struct {
+	// Status is the final status. Empty means SUCCESSFUL; PENDING means the
+	// transaction never resolves.
+	Status gomomo.TransactionStatus
+	// Reason is reported with FAILED, REJECTED and TIMEOUT statuses
+	Reason string
+	// PendingPolls is the number of status checks answered with PENDING
+	// before the final status is reported
+	PendingPolls int
+}
+
+// Script makes every later transaction for the MSISDN resolve as outcome,
+// e.g. Outcome{Status: gomomo.Rejected} or Outcome{PendingPolls: 3}. Like
+// MTN's sandbox test numbers, a scripted MSISDN needs no wallet: without one,
+// successful transactions only move funds in the merchant account.
+func (s *Server) Script(msisdn string, outcome Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outcomes[msisdn] = outcome
+}
+
+// Transaction is a request-to-pay or transfer received by the server
+type Transaction struct {
+	ReferenceID            string
+	Product                string // collection, disbursement or remittance
+	Kind                   string // requesttopay or transfer
+	Party                  gomomo.PartyInfo
+	Amount                 gomomo.Money
+	ExternalID             string
+	PayerMessage           string
+	PayeeNote              string
+	CallbackURL            string
+	Status                 gomomo.TransactionStatus
+	Reason                 string
+	FinancialTransactionID string
+	Polls                  int
+
+	outcome  Outcome
+	scripted bool // The party has a scripted outcome
+}
+
+// transactionPayload is the request body of request-to-pay and transfer
+type transactionPayload struct {
+	Amount       string           `json:"amount"`
+	Currency     string           `json:"currency"`
+	ExternalID   string           `json:"externalId"`
+	Payer        gomomo.PartyInfo `json:"payer"`
+	Payee        gomomo.PartyInfo `json:"payee"`
+	PayerMessage string           `json:"payerMessage"`
+	PayeeNote    string           `json:"payeeNote"`
+}
+
+// handleCreate accepts a request-to-pay or transfer
+func (s *Server) handleCreate(product, kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		referenceID := r.Header.Get("X-Reference-Id")
+		if _, err := uuid.Parse(referenceID); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REFERENCE_ID", "X-Reference-Id must be a UUID")
+			return
+		}
+
+		var payload transactionPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Invalid request body: %v", err))
+			return
+		}
+		amount, err := gomomo.ParseMoney(payload.Amount, payload.Currency)
+		if err != nil || amount.Amount <= 0 {
+			writeError(w, http.StatusBadRequest, "INVALID_AMOUNT", "Invalid amount.")
+			return
+		}
+
+		party := payload.Payee
+		if kind == "requesttopay" {
+			party = payload.Payer
+		}
+		if party.PartyIDType == "" || party.PartyID == "" {
+			writeError(w, http.StatusBadRequest, "INVALID_PARTY", "Missing party.")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if amount.Currency != s.currency {
+			writeError(w, http.StatusInternalServerError, "INVALID_CURRENCY", "Currency not supported.")
+			return
+		}
+		if _, ok := s.transactions[referenceID]; ok {
+			writeError(w, http.StatusConflict, "RESOURCE_ALREADY_EXIST", "Duplicated reference id. Creation of resource failed.")
+			return
+		}
+
+		outcome, scripted := s.outcomes[party.PartyID]
+		tx := &Transaction{
+			ReferenceID:  referenceID,
+			Product:      product,
+			Kind:         kind,
+			Party:        party,
+			Amount:       amount,
+			ExternalID:   payload.ExternalID,
+			PayerMessage: payload.PayerMessage,
+			PayeeNote:    payload.PayeeNote,
+			CallbackURL:  r.Header.Get("X-Callback-Url"),
+			Status:       gomomo.Pending,
+			outcome:      outcome,
+			scripted:     scripted,
+		}
+		s.transactions[referenceID] = tx
+		if tx.outcome.PendingPolls == 0 {
+			s.settle(tx)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// handleStatus reports a transaction's status, settling it once its
+// scripted pending polls are used up
+func (s *Server) handleStatus(product, kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		tx, ok := s.transactions[r.PathValue("referenceId")]
+		if !ok || tx.Product != product || tx.Kind != kind {
+			writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Requested resource was not found.")
+			return
+		}
+
+		tx.Polls++
+		if tx.Status == gomomo.Pending && tx.Polls > tx.outcome.PendingPolls {
+			s.settle(tx)
+		}
+
+		response := gomomo.TransactionStatusResponse{
+			Amount:                 tx.Amount.AmountString(),
+			Currency:               tx.Amount.Currency,
+			ExternalID:             tx.ExternalID,
+			PayerMessage:           tx.PayerMessage,
+			PayeeNote:              tx.PayeeNote,
+			Status:                 tx.Status,
+			Reason:                 tx.Reason,
+			FinancialTransactionID: tx.FinancialTransactionID,
+		}
+		if kind == "requesttopay" {
+			response.Payer = tx.Party
+		} else {
+			response.Payee = tx.Party
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// settle resolves a transaction to its final status and moves the funds
+// between the subscriber's wallet and the product's merchant account
+func (s *Server) settle(tx *Transaction) {
+	status := tx.outcome.Status
+	if status == "" {
+		status = gomomo.Successful
+	}
+	if status == gomomo.Pending {
+		return
+	}
+	if status != gomomo.Successful {
+		tx.Status = status
+		tx.Reason = tx.outcome.Reason
+		return
+	}
+
+	wallet := s.wallets[tx.Party.PartyID]
+	if !strings.EqualFold(string(tx.Party.PartyIDType), string(gomomo.MSISDN)) {
+		wallet = nil
+	}
+	account := s.accountBalance(tx.Product, tx.Amount.Currency)
+
+	switch {
+	case wallet == nil && !tx.scripted && tx.Kind == "requesttopay":
+		s.fail(tx, ReasonPayerNotFound)
+	case wallet == nil && !tx.scripted:
+		s.fail(tx, ReasonPayeeNotFound)
+	case wallet != nil && wallet.Inactive:
+		s.fail(tx, ReasonNotAllowed)
+	case tx.Kind == "requesttopay" && wallet != nil && wallet.Balance.Amount < tx.Amount.Amount:
+		s.fail(tx, ReasonNotEnoughFunds)
+	case tx.Kind == "transfer" && account.Amount < tx.Amount.Amount:
+		s.fail(tx, ReasonNotEnoughFunds)
+	case tx.Kind == "requesttopay":
+		if wallet != nil {
+			wallet.Balance.Amount -= tx.Amount.Amount
+		}
+		account.Amount += tx.Amount.Amount
+		s.setAccountBalance(tx.Product, account)
+		s.succeed(tx)
+	default:
+		if wallet != nil {
+			wallet.Balance.Amount += tx.Amount.Amount
+		}
+		account.Amount -= tx.Amount.Amount
+		s.setAccountBalance(tx.Product, account)
+		s.succeed(tx)
+	}
+}
+
+func (s *Server) fail(tx *Transaction, reason string) {
+	tx.Status = gomomo.Failed
+	tx.Reason = reason
+}
+
+func (s *Server) succeed(tx *Transaction) {
+	tx.Status = gomomo.Successful
+	s.settled++
+	tx.FinancialTransactionID = fmt.Sprintf("%d", 100000000+s.settled)
+}
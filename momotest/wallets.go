@@ -0,0 +1,89 @@
+package momotest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sir-george2500/gomomo"
+)
+
+// Wallet is a subscriber's MoMo wallet
+type Wallet struct {
+	MSISDN   string
+	Balance  gomomo.Money
+	Info     gomomo.AccountHolderInfo
+	Inactive bool // Inactive wallets report active=false and reject payments
+}
+
+// AddWallet registers a subscriber wallet with an opening balance. The
+// account holder's status defaults to "ACTIVE" unless the wallet is inactive.
+func (s *Server) AddWallet(wallet Wallet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if wallet.Info.Status == "" {
+		wallet.Info.Status = "ACTIVE"
+		if wallet.Inactive {
+			wallet.Info.Status = "INACTIVE"
+		}
+	}
+	if wallet.Balance.Currency == "" {
+		wallet.Balance.Currency = s.currency
+	}
+	s.wallets[wallet.MSISDN] = &wallet
+}
+
+// Wallet returns a copy of the wallet with the given MSISDN
+func (s *Server) Wallet(msisdn string) (Wallet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wallet, ok := s.wallets[msisdn]
+	if !ok {
+		return Wallet{}, false
+	}
+	return *wallet, true
+}
+
+// handleBalance returns a merchant account balance
+func (s *Server) handleBalance(product string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		currency := strings.ToUpper(r.PathValue("currency"))
+		if currency == "" {
+			currency = s.currency
+		}
+		balance := s.accountBalance(product, currency)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, map[string]string{
+			"availableBalance": balance.AmountString(),
+			"currency":         balance.Currency,
+		})
+	}
+}
+
+// handleBasicUserInfo returns the account holder's details
+func (s *Server) handleBasicUserInfo(w http.ResponseWriter, r *http.Request) {
+	wallet, ok := s.lookupWallet(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Requested resource was not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet.Info)
+}
+
+// handleActive reports whether the account holder is active
+func (s *Server) handleActive(w http.ResponseWriter, r *http.Request) {
+	wallet, ok := s.lookupWallet(r)
+	writeJSON(w, http.StatusOK, map[string]bool{"result": ok && !wallet.Inactive})
+}
+
+// lookupWallet finds the wallet addressed by an accountholder path. Only
+// MSISDN wallets are modelled.
+func (s *Server) lookupWallet(r *http.Request) (Wallet, bool) {
+	if !strings.EqualFold(r.PathValue("type"), string(gomomo.MSISDN)) {
+		return Wallet{}, false
+	}
+	return s.Wallet(r.PathValue("id"))
+}
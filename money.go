@@ -0,0 +1,163 @@
+package gomomo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of a currency, held as an integer number of minor
+// units (e.g. cents for EUR, whole shillings for UGX)
+type Money struct {
+	Amount   int64  // Amount in minor units
+	Currency string // ISO 4217 currency code
+}
+
+// Currencies whose minor unit exponent differs from 2
+var currencyExponents = map[string]int{
+	// Zero-decimal currencies, including most MTN markets in Central and West Africa
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+	// Three-decimal currencies
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns the number of decimal places used by a currency
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// NewMoney creates an amount from minor units
+func NewMoney(amount int64, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: strings.ToUpper(currency),
+	}
+}
+
+// ParseMoney parses a decimal amount such as "10.50" in the given currency.
+// Digits beyond the currency's exponent are only accepted if they are zero.
+func ParseMoney(amount, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if err := validateCurrency(currency); err != nil {
+		return Money{}, err
+	}
+
+	value := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal amount", ErrInvalidAmount, amount)
+	}
+
+	exponent := CurrencyExponent(currency)
+	if len(fraction) > exponent {
+		if strings.Trim(fraction[exponent:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %q has more than %d decimal places for %s", ErrInvalidAmount, amount, exponent, currency)
+		}
+		fraction = fraction[:exponent]
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, amount)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MoneyFromFloat converts a float amount, rounding it to the currency's
+// minor unit. Prefer NewMoney or ParseMoney, which are exact.
+func MoneyFromFloat(amount float64, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if err := validateCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+
+	minor := math.Round(amount * math.Pow10(CurrencyExponent(currency)))
+	if math.Abs(minor) >= math.MaxInt64 {
+		return Money{}, fmt.Errorf("%w: %v is out of range", ErrInvalidAmount, amount)
+	}
+
+	return Money{Amount: int64(minor), Currency: currency}, nil
+}
+
+// AmountString formats the amount with the currency's number of decimals,
+// as expected by the API (e.g. "10.50" for EUR, "5000" for UGX)
+func (m Money) AmountString() string {
+	exponent := CurrencyExponent(m.Currency)
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// String formats the amount followed by its currency, e.g. "10.50 EUR"
+func (m Money) String() string {
+	return m.AmountString() + " " + m.Currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// validate checks that the amount can be sent in a payment request
+func (m Money) validate() error {
+	if err := validateCurrency(m.Currency); err != nil {
+		return err
+	}
+	if m.Amount <= 0 {
+		return fmt.Errorf("%w: %s must be positive", ErrInvalidAmount, m)
+	}
+	return nil
+}
+
+// validateCurrency checks that a currency looks like an ISO 4217 code
+func validateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("%w: %q is not an ISO 4217 currency code", ErrInvalidAmount, currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q is not an ISO 4217 currency code", ErrInvalidAmount, currency)
+		}
+	}
+	return nil
+}
+
+// isDigits reports whether s only contains ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
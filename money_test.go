@@ -0,0 +1,116 @@
+package gomomo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     Money
+		wantStr  string
+	}{
+		{"10.50", "EUR", Money{1050, "EUR"}, "10.50 EUR"},
+		{"10.5", "eur", Money{1050, "EUR"}, "10.50 EUR"},
+		{"10", "EUR", Money{1000, "EUR"}, "10.00 EUR"},
+		{"0.01", "EUR", Money{1, "EUR"}, "0.01 EUR"},
+		{"0", "EUR", Money{0, "EUR"}, "0.00 EUR"},
+		{" 7.00 ", "EUR", Money{700, "EUR"}, "7.00 EUR"},
+		{"10.500", "EUR", Money{1050, "EUR"}, "10.50 EUR"},
+		{"-3.25", "EUR", Money{-325, "EUR"}, "-3.25 EUR"},
+		{"5000", "UGX", Money{5000, "UGX"}, "5000 UGX"},
+		{"5000.00", "UGX", Money{5000, "UGX"}, "5000 UGX"},
+		{"1.234", "KWD", Money{1234, "KWD"}, "1.234 KWD"},
+		{"0.005", "KWD", Money{5, "KWD"}, "0.005 KWD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, tt.currency)
+			if err != nil {
+				t.Fatalf("ParseMoney: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.wantStr {
+				t.Errorf("String = %q, want %q", got.String(), tt.wantStr)
+			}
+
+			// The formatted amount parses back to the same value
+			again, err := ParseMoney(got.AmountString(), got.Currency)
+			if err != nil || again != got {
+				t.Errorf("round trip = %+v, %v; want %+v", again, err, got)
+			}
+		})
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+	}{
+		{"", "EUR"},
+		{"abc", "EUR"},
+		{"1,50", "EUR"},
+		{".50", "EUR"},
+		{"1.2.3", "EUR"},
+		{"1e3", "EUR"},
+		{"10.501", "EUR"},
+		{"10.5", "UGX"},
+		{"99999999999999999999", "EUR"},
+		{"10", ""},
+		{"10", "EURO"},
+		{"10", "E1R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			if got, err := ParseMoney(tt.amount, tt.currency); !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("ParseMoney = %+v, %v; want ErrInvalidAmount", got, err)
+			}
+		})
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     Money
+	}{
+		{10.5, "EUR", Money{1050, "EUR"}},
+		{0.1 + 0.2, "EUR", Money{30, "EUR"}},
+		{19.999, "EUR", Money{2000, "EUR"}},
+		{5000.4, "UGX", Money{5000, "UGX"}},
+		{1.2345, "KWD", Money{1235, "KWD"}},
+	}
+
+	for _, tt := range tests {
+		got, err := MoneyFromFloat(tt.amount, tt.currency)
+		if err != nil || got != tt.want {
+			t.Errorf("MoneyFromFloat(%v, %s) = %+v, %v; want %+v", tt.amount, tt.currency, got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyValidate(t *testing.T) {
+	tests := []struct {
+		money   Money
+		wantErr bool
+	}{
+		{NewMoney(1, "EUR"), false},
+		{NewMoney(0, "EUR"), true},
+		{NewMoney(-100, "EUR"), true},
+		{Money{Amount: 100}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.money.validate(); (err != nil) != tt.wantErr {
+			t.Errorf("validate(%+v) = %v, wantErr %v", tt.money, err, tt.wantErr)
+		}
+	}
+}
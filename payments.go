@@ -0,0 +1,119 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// PaymentOptions contains optional parameters for bill payments
+type PaymentOptions struct {
+	ReferenceID           string // Custom reference ID (generated if empty)
+	ExternalTransactionID string // Custom external transaction ID (generated if empty)
+	CouponID              string // Coupon applied to the payment
+	ProductID             string // Product being paid for
+	ProductOfferingID     string // Product offering being paid for
+	ReceiverMessage       string // Message to the service provider
+	SenderNote            string // Note from the customer
+	MaxNumberOfRetries    int    // How often MTN retries the payment with the provider
+	IncludeSenderCharges  bool   // Add the sender's charges to the amount
+	CallbackURL           string // Callback URL (built from the config's callback path if empty)
+}
+
+// PaymentMoney is the wire format of a bill payment amount
+type PaymentMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// PaymentErrorReason explains why a bill payment failed
+type PaymentErrorReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PaymentPayload represents the payload for a bill payment
+type PaymentPayload struct {
+	ExternalTransactionID   string       `json:"externalTransactionId"`
+	Money                   PaymentMoney `json:"money"`
+	CustomerReference       string       `json:"customerReference"`
+	ServiceProviderUserName string       `json:"serviceProviderUserName"`
+	CouponID                string       `json:"couponId,omitempty"`
+	ProductID               string       `json:"productId,omitempty"`
+	ProductOfferingID       string       `json:"productOfferingId,omitempty"`
+	ReceiverMessage         string       `json:"receiverMessage,omitempty"`
+	SenderNote              string       `json:"senderNote,omitempty"`
+	MaxNumberOfRetries      int          `json:"maxNumberOfRetries,omitempty"`
+	IncludeSenderCharges    bool         `json:"includeSenderCharges"`
+}
+
+// PaymentStatusResponse represents the response from a bill payment status check
+type PaymentStatusResponse struct {
+	ReferenceID            string              `json:"referenceId"`
+	Status                 TransactionStatus   `json:"status"`
+	FinancialTransactionID string              `json:"financialTransactionId,omitempty"`
+	Reason                 *PaymentErrorReason `json:"reason,omitempty"`
+}
+
+// CreatePayment pays an external bill of an exact amount to a service
+// provider, identifying the customer by their reference with the provider
+// (e.g. a meter or account number)
+func (s *CollectionService) CreatePayment(ctx context.Context, customerReference, serviceProviderUserName string, amount Money, opts *PaymentOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+	if customerReference == "" || serviceProviderUserName == "" {
+		return "", fmt.Errorf("%w: customer reference and service provider are required", ErrInvalidRequest)
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &PaymentOptions{}
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external transaction ID
+	externalTransactionID := opts.ExternalTransactionID
+	if externalTransactionID == "" {
+		externalTransactionID = uuid.New().String()
+	}
+
+	// Create request payload
+	payload := PaymentPayload{
+		ExternalTransactionID:   externalTransactionID,
+		Money:                   PaymentMoney{Amount: amount.AmountString(), Currency: amount.Currency},
+		CustomerReference:       customerReference,
+		ServiceProviderUserName: serviceProviderUserName,
+		CouponID:                opts.CouponID,
+		ProductID:               opts.ProductID,
+		ProductOfferingID:       opts.ProductOfferingID,
+		ReceiverMessage:         opts.ReceiverMessage,
+		SenderNote:              opts.SenderNote,
+		MaxNumberOfRetries:      opts.MaxNumberOfRetries,
+		IncludeSenderCharges:    opts.IncludeSenderCharges,
+	}
+
+	err := s.post(ctx, "/collection/v2_0/payment", payload, referenceID, "", CallbackPayment, opts.CallbackURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating payment: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetPaymentStatus checks the status of a bill payment
+func (s *CollectionService) GetPaymentStatus(ctx context.Context, referenceID string) (*PaymentStatusResponse, error) {
+	var result PaymentStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/collection/v2_0/payment/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking payment status: %w", err)
+	}
+
+	return &result, nil
+}
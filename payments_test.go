@@ -0,0 +1,79 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreatePaymentPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Money
+		want   string
+	}{
+		{"minor units", NewMoney(1050, "EUR"), `{"amount":"10.50","currency":"EUR"}`},
+		{"no minor units", NewMoney(5000, "UGX"), `{"amount":"5000","currency":"UGX"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /collection/token/", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+			})
+			mux.HandleFunc("POST /collection/v2_0/payment", func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusAccepted)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			config := newTestConfig(t, srv.URL)
+			client := NewClient(config)
+			collection := NewCollectionService(client, config, NewAuthService(client, config))
+
+			if _, err := collection.CreatePayment(context.Background(), "meter-42", "utility", tt.amount, nil); err != nil {
+				t.Fatalf("CreatePayment: %v", err)
+			}
+
+			var payload map[string]json.RawMessage
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("decoding payload %s: %v", body, err)
+			}
+			if got := string(payload["money"]); got != tt.want {
+				t.Errorf("money = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStatusReason(t *testing.T) {
+	tests := []struct {
+		body       string
+		wantStatus TransactionStatus
+		wantReason string
+	}{
+		{`{"referenceId":"ref","status":"SUCCESSFUL","financialTransactionId":"123"}`, Successful, ""},
+		{`{"referenceId":"ref","status":"FAILED","reason":{"code":"PAYER_NOT_FOUND","message":"Payer not found"}}`, Failed, "PAYER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		var status PaymentStatusResponse
+		if err := json.Unmarshal([]byte(tt.body), &status); err != nil {
+			t.Fatalf("decoding %s: %v", tt.body, err)
+		}
+		reason := ""
+		if status.Reason != nil {
+			reason = status.Reason.Code
+		}
+		if status.Status != tt.wantStatus || reason != tt.wantReason {
+			t.Errorf("%s decoded as %s/%q, want %s/%q", tt.body, status.Status, reason, tt.wantStatus, tt.wantReason)
+		}
+	}
+}
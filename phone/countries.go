@@ -0,0 +1,31 @@
+package phone
+
+// Country describes the numbering plan of a country served by MTN MoMo
+type Country struct {
+	Code              string   // ISO 3166-1 alpha-2 code, e.g. "UG"
+	Name              string   // Country name
+	CallingCode       string   // International calling code without "+", e.g. "256"
+	TrunkPrefix       string   // National trunk prefix stripped from local numbers, e.g. "0"
+	Lengths           []int    // Valid lengths of the national significant number
+	MobilePrefixes    []string // Valid leading digits of mobile numbers
+	TargetEnvironment string   // MTN MoMo X-Target-Environment, e.g. "mtnuganda"
+}
+
+// Countries lists the numbering plans known to the package
+var Countries = []Country{
+	{Code: "BJ", Name: "Benin", CallingCode: "229", Lengths: []int{8, 10}, MobilePrefixes: []string{"01", "4", "5", "6", "9"}, TargetEnvironment: "mtnbenin"},
+	{Code: "CG", Name: "Congo", CallingCode: "242", Lengths: []int{9}, MobilePrefixes: []string{"04", "05", "06"}, TargetEnvironment: "mtncongo"},
+	{Code: "CI", Name: "Côte d'Ivoire", CallingCode: "225", Lengths: []int{10}, MobilePrefixes: []string{"01", "05", "07"}, TargetEnvironment: "mtnivorycoast"},
+	{Code: "CM", Name: "Cameroon", CallingCode: "237", Lengths: []int{9}, MobilePrefixes: []string{"6"}, TargetEnvironment: "mtncameroon"},
+	{Code: "GH", Name: "Ghana", CallingCode: "233", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"2", "5"}, TargetEnvironment: "mtnghana"},
+	{Code: "GN", Name: "Guinea", CallingCode: "224", Lengths: []int{9}, MobilePrefixes: []string{"6"}, TargetEnvironment: "mtnguineaconakry"},
+	{Code: "GW", Name: "Guinea-Bissau", CallingCode: "245", Lengths: []int{9}, MobilePrefixes: []string{"9"}, TargetEnvironment: "mtnguineabissau"},
+	{Code: "LR", Name: "Liberia", CallingCode: "231", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"55", "77", "88"}, TargetEnvironment: "mtnliberia"},
+	{Code: "NG", Name: "Nigeria", CallingCode: "234", TrunkPrefix: "0", Lengths: []int{10}, MobilePrefixes: []string{"7", "8", "9"}, TargetEnvironment: "mtnnigeria"},
+	{Code: "RW", Name: "Rwanda", CallingCode: "250", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"7"}, TargetEnvironment: "mtnrwanda"},
+	{Code: "SS", Name: "South Sudan", CallingCode: "211", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"9"}, TargetEnvironment: "mtnsouthsudan"},
+	{Code: "SZ", Name: "Eswatini", CallingCode: "268", Lengths: []int{8}, MobilePrefixes: []string{"7"}, TargetEnvironment: "mtnswaziland"},
+	{Code: "UG", Name: "Uganda", CallingCode: "256", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"7"}, TargetEnvironment: "mtnuganda"},
+	{Code: "ZA", Name: "South Africa", CallingCode: "27", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"6", "7", "8"}, TargetEnvironment: "mtnsouthafrica"},
+	{Code: "ZM", Name: "Zambia", CallingCode: "260", TrunkPrefix: "0", Lengths: []int{9}, MobilePrefixes: []string{"7", "9"}, TargetEnvironment: "mtnzambia"},
+}
@@ -0,0 +1,168 @@
+// Package phone normalizes and validates MSISDNs for the markets served by
+// MTN MoMo.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidNumber is matched by every error returned for an impossible number
+var ErrInvalidNumber = errors.New("invalid phone number")
+
+// Error describes why a phone number was rejected
+type Error struct {
+	Number  string // Number as given by the caller
+	Country string // ISO code of the country it was checked against, if any
+	Reason  string
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Country == "" {
+		return fmt.Sprintf("invalid phone number %q: %s", e.Number, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s phone number %q: %s", e.Country, e.Number, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrInvalidNumber) match any Error
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidNumber
+}
+
+// Number is a validated phone number
+type Number struct {
+	CallingCode string // International calling code, empty if the country is unknown
+	National    string // National significant number, or all digits if the country is unknown
+}
+
+// MSISDN returns the number in the international form used by the MTN MoMo
+// API: calling code and national number, without "+"
+func (n Number) MSISDN() string {
+	return n.CallingCode + n.National
+}
+
+// E164 returns the number in E.164 form, e.g. "+256772123456"
+func (n Number) E164() string {
+	return "+" + n.MSISDN()
+}
+
+// String implements fmt.Stringer
+func (n Number) String() string {
+	return n.E164()
+}
+
+// Lookup finds a country by ISO code ("UG"), calling code ("256") or MTN
+// MoMo target environment ("mtnuganda")
+func Lookup(key string) (Country, bool) {
+	key = strings.TrimPrefix(strings.TrimSpace(key), "+")
+	for _, country := range Countries {
+		if strings.EqualFold(country.Code, key) ||
+			country.CallingCode == key ||
+			strings.EqualFold(country.TargetEnvironment, key) {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// Parse normalizes a phone number written in national or international form
+// (with "+", "00" or just the calling code). Spaces, dashes, dots and
+// parentheses are ignored and the trunk prefix is stripped. The number is
+// checked against the country's lengths and mobile prefixes; with a zero
+// Country only the number of digits is checked.
+func Parse(number string, country Country) (Number, error) {
+	fail := func(reason string) (Number, error) {
+		return Number{}, &Error{Number: number, Country: country.Code, Reason: reason}
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '\t':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(number))
+
+	international := false
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		international = true
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		international = true
+		digits = digits[2:]
+	}
+
+	if digits == "" {
+		return fail("no digits")
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fail(fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+
+	// Without a numbering plan we can only check the E.164 length limits
+	if country.CallingCode == "" {
+		if len(digits) < 6 || len(digits) > 15 {
+			return fail("must have between 6 and 15 digits")
+		}
+		return Number{National: digits}, nil
+	}
+
+	national := digits
+	switch {
+	case international:
+		if !strings.HasPrefix(digits, country.CallingCode) {
+			return fail("calling code is not +" + country.CallingCode)
+		}
+		national = digits[len(country.CallingCode):]
+	case strings.HasPrefix(digits, country.CallingCode) && country.validLength(len(digits)-len(country.CallingCode)):
+		national = digits[len(country.CallingCode):]
+	case country.TrunkPrefix != "" && strings.HasPrefix(digits, country.TrunkPrefix):
+		national = digits[len(country.TrunkPrefix):]
+	}
+
+	if !country.validLength(len(national)) {
+		return fail(fmt.Sprintf("national number must have %s digits", joinInts(country.Lengths)))
+	}
+	if !country.validPrefix(national) {
+		return fail("not a mobile number")
+	}
+
+	return Number{CallingCode: country.CallingCode, National: national}, nil
+}
+
+// validLength reports whether n is a valid national number length
+func (c Country) validLength(n int) bool {
+	for _, length := range c.Lengths {
+		if n == length {
+			return true
+		}
+	}
+	return false
+}
+
+// validPrefix reports whether a national number starts with a mobile prefix
+func (c Country) validPrefix(national string) bool {
+	if len(c.MobilePrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range c.MobilePrefixes {
+		if strings.HasPrefix(national, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinInts formats lengths as "8 or 10"
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, " or ")
+}
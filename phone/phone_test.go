@@ -0,0 +1,122 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCountries(t *testing.T) {
+	tests := []struct {
+		country string
+		valid   map[string]string // input -> MSISDN
+		invalid []string
+	}{
+		{"BJ", map[string]string{"0197123456": "2290197123456", "+229 97 12 34 56": "22997123456"}, []string{"2912345", "+22937123456"}},
+		{"CG", map[string]string{"061234567": "242061234567", "+242 05 123 4567": "242051234567"}, []string{"021234567", "06123456"}},
+		{"CI", map[string]string{"0512345678": "2250512345678", "+2250712345678": "2250712345678"}, []string{"0212345678", "051234567"}},
+		{"CM", map[string]string{"671234567": "237671234567", "237 6 71 23 45 67": "237671234567"}, []string{"271234567", "67123456"}},
+		{"GH", map[string]string{"0241234567": "233241234567", "+233 55 123 4567": "233551234567", "241234567": "233241234567"}, []string{"0341234567", "024123456"}},
+		{"GN", map[string]string{"621234567": "224621234567", "00224621234567": "224621234567"}, []string{"321234567", "+233621234567"}},
+		{"GW", map[string]string{"955123456": "245955123456", "+245955123456": "245955123456"}, []string{"455123456", "95512345"}},
+		{"LR", map[string]string{"0881234567": "231881234567", "+231 77 123 4567": "231771234567", "231551234567": "231551234567"}, []string{"0661234567", "088123456"}},
+		{"NG", map[string]string{"08031234567": "2348031234567", "+234 703 123 4567": "2347031234567"}, []string{"06031234567", "0803123456"}},
+		{"RW", map[string]string{"0781234567": "250781234567", "+250 78 123 4567": "250781234567"}, []string{"0281234567", "07812345678"}},
+		{"SS", map[string]string{"0921234567": "211921234567", "+211921234567": "211921234567"}, []string{"0121234567", "092123456"}},
+		{"SZ", map[string]string{"76123456": "26876123456", "+268 7612 3456": "26876123456"}, []string{"26123456", "7612345"}},
+		{"UG", map[string]string{"0772123456": "256772123456", "+256 (772) 123-456": "256772123456", "256772123456": "256772123456", "772123456": "256772123456"}, []string{"0412123456", "077212345", "+254772123456", "0772abc456"}},
+		{"ZA", map[string]string{"0831234567": "27831234567", "+27 63 123 4567": "27631234567"}, []string{"0211234567", "083123456"}},
+		{"ZM", map[string]string{"0961234567": "260961234567", "+260 76 123 4567": "260761234567"}, []string{"0211234567", "09612345678"}},
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.country] = true
+		country, ok := Lookup(tt.country)
+		if !ok {
+			t.Fatalf("Lookup(%q) failed", tt.country)
+		}
+
+		t.Run(tt.country, func(t *testing.T) {
+			for input, want := range tt.valid {
+				number, err := Parse(input, country)
+				if err != nil {
+					t.Errorf("Parse(%q): %v", input, err)
+					continue
+				}
+				if number.MSISDN() != want {
+					t.Errorf("Parse(%q).MSISDN() = %q, want %q", input, number.MSISDN(), want)
+				}
+				if number.E164() != "+"+want {
+					t.Errorf("Parse(%q).E164() = %q, want %q", input, number.E164(), "+"+want)
+				}
+			}
+			for _, input := range tt.invalid {
+				_, err := Parse(input, country)
+				if !errors.Is(err, ErrInvalidNumber) {
+					t.Errorf("Parse(%q) = %v, want ErrInvalidNumber", input, err)
+				}
+				var phoneErr *Error
+				if errors.As(err, &phoneErr) && phoneErr.Country != tt.country {
+					t.Errorf("Parse(%q) error country = %q, want %q", input, phoneErr.Country, tt.country)
+				}
+			}
+		})
+	}
+
+	for _, country := range Countries {
+		if !covered[country.Code] {
+			t.Errorf("no test cases for %s", country.Code)
+		}
+	}
+}
+
+func TestParseWithoutCountry(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"46733123450", "46733123450", false},
+		{"+46 733 123 450", "46733123450", false},
+		{"123456", "123456", false},
+		{"12345", "", true},
+		{"1234567890123456", "", true},
+		{"", "", true},
+		{"phone", "", true},
+	}
+
+	for _, tt := range tests {
+		number, err := Parse(tt.input, Country{})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if number.MSISDN() != tt.want {
+			t.Errorf("Parse(%q).MSISDN() = %q, want %q", tt.input, number.MSISDN(), tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"UG", "UG", true},
+		{"ug", "UG", true},
+		{"256", "UG", true},
+		{"+233", "GH", true},
+		{"mtnliberia", "LR", true},
+		{"MTNZAMBIA", "ZM", true},
+		{"XX", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		country, ok := Lookup(tt.key)
+		if ok != tt.ok || country.Code != tt.want {
+			t.Errorf("Lookup(%q) = %q, %v; want %q, %v", tt.key, country.Code, ok, tt.want, tt.ok)
+		}
+	}
+}
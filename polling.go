@@ -0,0 +1,101 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WaitOptions controls how WaitForFinalStatus polls for a transaction status
+type WaitOptions struct {
+	InitialInterval time.Duration // Delay before the second poll (default 2s)
+	MaxInterval     time.Duration // Upper bound for the delay between polls (default 30s)
+	Multiplier      float64       // Factor applied to the delay after each poll (default 1.5)
+	MaxNotFound     int           // Consecutive 404s tolerated before giving up (default 5)
+
+	// OnPoll is called after every poll with the attempt number (starting
+	// at 1) and the status or error returned by the API
+	OnPoll func(attempt int, status *TransactionStatusResponse, err error)
+}
+
+// statusFunc fetches the current status of a transaction
+type statusFunc func(ctx context.Context, referenceID string) (*TransactionStatusResponse, error)
+
+// waitForFinalStatus polls until a transaction leaves the pending state or
+// the context expires. Failed, rejected and timed out transactions are
+// returned together with a *TransactionError, and unknown statuses with
+// ErrInvalidResponse. A reference ID that is still not found after
+// MaxNotFound consecutive polls is returned with the ErrNotFound error.
+func waitForFinalStatus(ctx context.Context, referenceID string, opts *WaitOptions, fetch statusFunc) (*TransactionStatusResponse, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+
+	interval := opts.InitialInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	multiplier := opts.Multiplier
+	if multiplier < 1 {
+		multiplier = 1.5
+	}
+	maxNotFound := opts.MaxNotFound
+	if maxNotFound <= 0 {
+		maxNotFound = 5
+	}
+
+	var last *TransactionStatusResponse
+	notFound := 0
+	for attempt := 1; ; attempt++ {
+		status, err := fetch(ctx, referenceID)
+		if opts.OnPoll != nil {
+			opts.OnPoll(attempt, status, err)
+		}
+
+		if err != nil {
+			// The transaction may not be visible yet right after creation,
+			// but a reference ID that stays unknown was never created
+			if errors.Is(err, ErrNotFound) {
+				notFound++
+				if notFound >= maxNotFound {
+					return last, err
+				}
+			} else if ctx.Err() == nil && !isRetryableError(err) {
+				// Anything else that survived the client's retries is permanent
+				return last, err
+			}
+		} else {
+			notFound = 0
+			last = status
+			switch status.Status {
+			case Successful:
+				return status, nil
+			case Failed, Rejected, Timeout:
+				return status, &TransactionError{
+					ReferenceID: referenceID,
+					Status:      status.Status,
+					Reason:      status.Reason,
+				}
+			case Pending, "":
+				// Keep polling
+			default:
+				// Never report a status the SDK does not understand as success
+				return status, fmt.Errorf("%w: unknown status %q for transaction %s", ErrInvalidResponse, status.Status, referenceID)
+			}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return last, fmt.Errorf("error waiting for final status of %s: %w", referenceID, err)
+		}
+
+		interval = time.Duration(float64(interval) * multiplier)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// scriptedStatuses returns a statusFunc answering with each status in turn
+func scriptedStatuses(statuses ...TransactionStatus) (statusFunc, *int) {
+	calls := 0
+	return func(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		return &TransactionStatusResponse{Status: status, Reason: "REASON"}, nil
+	}, &calls
+}
+
+func TestWaitForFinalStatus(t *testing.T) {
+	fast := &WaitOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	tests := []struct {
+		name       string
+		statuses   []TransactionStatus
+		wantStatus TransactionStatus
+		wantPolls  int
+		wantErr    error
+	}{
+		{"immediate success", []TransactionStatus{Successful}, Successful, 1, nil},
+		{"pending then success", []TransactionStatus{Pending, Pending, Successful}, Successful, 3, nil},
+		{"failed", []TransactionStatus{Pending, Failed}, Failed, 2, ErrTransactionFailed},
+		{"rejected", []TransactionStatus{Rejected}, Rejected, 1, ErrTransactionFailed},
+		{"timeout", []TransactionStatus{Timeout}, Timeout, 1, ErrTransactionFailed},
+		{"unknown status", []TransactionStatus{Pending, "SETTLED"}, "SETTLED", 2, ErrInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch, calls := scriptedStatuses(tt.statuses...)
+			status, err := waitForFinalStatus(context.Background(), "ref", fast, fetch)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if status == nil || status.Status != tt.wantStatus {
+				t.Errorf("status = %+v, want %s", status, tt.wantStatus)
+			}
+			if *calls != tt.wantPolls {
+				t.Errorf("polled %d times, want %d", *calls, tt.wantPolls)
+			}
+		})
+	}
+}
+
+func TestWaitForFinalStatusTransactionError(t *testing.T) {
+	fetch, _ := scriptedStatuses(Rejected)
+	_, err := waitForFinalStatus(context.Background(), "ref", nil, fetch)
+
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("err = %v, want *TransactionError", err)
+	}
+	if txErr.ReferenceID != "ref" || txErr.Status != Rejected || txErr.Reason != "REASON" {
+		t.Errorf("TransactionError = %+v", txErr)
+	}
+}
+
+func TestWaitForFinalStatusContext(t *testing.T) {
+	fetch, _ := scriptedStatuses(Pending)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	status, err := waitForFinalStatus(ctx, "ref", &WaitOptions{InitialInterval: 5 * time.Millisecond}, fetch)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if status == nil || status.Status != Pending {
+		t.Errorf("status = %+v, want the last pending status", status)
+	}
+}
+
+func TestWaitForFinalStatusPermanentError(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+		calls++
+		if calls == 1 {
+			return nil, &MoMoError{StatusCode: 404}
+		}
+		return nil, &MoMoError{StatusCode: 400}
+	}
+
+	_, err := waitForFinalStatus(context.Background(), "ref", &WaitOptions{InitialInterval: time.Millisecond}, fetch)
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("err = %v, want ErrInvalidRequest", err)
+	}
+	if calls != 2 {
+		t.Errorf("polled %d times, want 2 (404 is retried, 400 is not)", calls)
+	}
+}
+
+func TestWaitForFinalStatusNotFound(t *testing.T) {
+	tests := []struct {
+		name        string
+		responses   []int // HTTP status per poll, 200 meaning pending
+		maxNotFound int
+		wantPolls   int
+		wantErr     error
+	}{
+		{"gives up after default", []int{404}, 0, 5, ErrNotFound},
+		{"gives up after MaxNotFound", []int{404}, 2, 2, ErrNotFound},
+		{"visible after a few polls", []int{404, 404, 200, 200}, 3, 4, nil},
+		{"count resets when found", []int{404, 200, 404, 200, 404, 404}, 2, 6, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			fetch := func(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+				code := tt.responses[len(tt.responses)-1]
+				if calls < len(tt.responses) {
+					code = tt.responses[calls]
+				}
+				calls++
+				if code == 404 {
+					return nil, &MoMoError{StatusCode: 404}
+				}
+				status := Pending
+				if calls == len(tt.responses) && tt.wantErr == nil {
+					status = Successful
+				}
+				return &TransactionStatusResponse{Status: status}, nil
+			}
+
+			opts := &WaitOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxNotFound: tt.maxNotFound}
+			_, err := waitForFinalStatus(context.Background(), "ref", opts, fetch)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantPolls {
+				t.Errorf("polled %d times, want %d", calls, tt.wantPolls)
+			}
+		})
+	}
+}
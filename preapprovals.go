@@ -0,0 +1,191 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PreApprovalOptions contains optional parameters for pre-approvals
+type PreApprovalOptions struct {
+	ReferenceID  string        // Custom reference ID (generated if empty)
+	Currency     string        // Payer currency (default currency if empty)
+	PayerMessage string        // Message to the payer
+	Validity     time.Duration // How long the pre-approval stays valid (1 hour if zero)
+	CallbackURL  string        // Callback URL (built from the config's callback path if empty)
+}
+
+// PreApprovalPayload represents the payload for a pre-approval request
+type PreApprovalPayload struct {
+	Payer         PartyInfo `json:"payer"`
+	PayerCurrency string    `json:"payerCurrency"`
+	PayerMessage  string    `json:"payerMessage"`
+	ValidityTime  int64     `json:"validityTime"`
+}
+
+// PreApprovalStatusResponse represents the response from a pre-approval status check
+type PreApprovalStatusResponse struct {
+	Payer              PartyInfo         `json:"payer"`
+	PayerCurrency      string            `json:"payerCurrency"`
+	PayerMessage       string            `json:"payerMessage,omitempty"`
+	Status             TransactionStatus `json:"status"`
+	ExpirationDateTime string            `json:"expirationDateTime,omitempty"`
+	Reason             string            `json:"reason,omitempty"`
+}
+
+// Expired reports whether the pre-approval has passed its expiration time.
+// It reports false if the expiration time is missing or cannot be parsed.
+func (r *PreApprovalStatusResponse) Expired() bool {
+	if r.ExpirationDateTime == "" {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, r.ExpirationDateTime)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(expiry)
+}
+
+// ApprovedPreApproval is a pre-approval granted by an account holder
+type ApprovedPreApproval struct {
+	PreApprovalID  string `json:"preApprovalId"`
+	ToFri          string `json:"toFri"`
+	FromFri        string `json:"fromFri"`
+	FromCurrency   string `json:"fromCurrency"`
+	CreatedTime    string `json:"createdTime"`
+	ApprovedTime   string `json:"approvedTime"`
+	ExpiryTime     string `json:"expiryTime"`
+	Status         string `json:"status"`
+	Message        string `json:"message"`
+	Frequency      string `json:"frequency"`
+	StartDate      string `json:"startDate"`
+	LastUsedDate   string `json:"lastUsedDate"`
+	Offer          string `json:"offer"`
+	ExternalID     string `json:"externalId"`
+	MaxDebitAmount string `json:"maxDebitAmount"`
+}
+
+// CreatePreApproval asks an account holder to pre-approve the merchant, so
+// that later payment requests don't each need the payer's approval. The
+// returned reference ID identifies the pre-approval.
+func (s *CollectionService) CreatePreApproval(ctx context.Context, phone string, opts *PreApprovalOptions) (string, error) {
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return "", err
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &PreApprovalOptions{}
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	validity := opts.Validity
+	if validity <= 0 {
+		validity = time.Hour
+	}
+
+	// Create request payload
+	payload := PreApprovalPayload{
+		Payer: PartyInfo{
+			PartyIDType: MSISDN,
+			PartyID:     msisdn,
+		},
+		PayerCurrency: defaultIfEmpty(opts.Currency, s.config.Currency),
+		PayerMessage:  defaultIfEmpty(opts.PayerMessage, "Pre-approval request"),
+		ValidityTime:  int64(validity / time.Second),
+	}
+
+	err = s.post(ctx, "/collection/v2_0/preapproval", payload, referenceID, "", CallbackPreApproval, opts.CallbackURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error making pre-approval: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetPreApprovalStatus checks the status of a pre-approval
+func (s *CollectionService) GetPreApprovalStatus(ctx context.Context, preApprovalID string) (*PreApprovalStatusResponse, error) {
+	var result PreApprovalStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/collection/v2_0/preapproval/%s", preApprovalID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking pre-approval status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetApprovedPreApprovals lists the pre-approvals granted by an account holder
+func (s *CollectionService) GetApprovedPreApprovals(ctx context.Context, accountHolder PartyInfo) ([]ApprovedPreApproval, error) {
+	party, err := s.config.normalizeParty(accountHolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ApprovedPreApproval
+	err = s.get(ctx, "/collection/v1_0/preapprovals/"+partyPath(party), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting approved pre-approvals: %w", err)
+	}
+
+	return result, nil
+}
+
+// CancelPreApproval cancels a pre-approval
+func (s *CollectionService) CancelPreApproval(ctx context.Context, preApprovalID string) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "collection")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	req := Request{
+		Method: http.MethodDelete,
+		Path:   fmt.Sprintf("/collection/v1_0/preapproval/%s", preApprovalID),
+		Headers: map[string]string{
+			"Authorization":             "Bearer " + token,
+			"X-Target-Environment":      s.config.TargetEnvironment,
+			"Ocp-Apim-Subscription-Key": s.config.SubscriptionKey,
+		},
+	}
+
+	err = s.client.DoRequest(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error cancelling pre-approval: %w", err)
+	}
+
+	return nil
+}
+
+// checkPreApproval verifies that a pre-approval is granted, unexpired and
+// covers the payer and currency of a payment request
+func (s *CollectionService) checkPreApproval(ctx context.Context, preApprovalID string, payer PartyInfo, currency string) error {
+	status, err := s.GetPreApprovalStatus(ctx, preApprovalID)
+	if err != nil {
+		return err
+	}
+
+	if status.Status != Successful {
+		return fmt.Errorf("%w: pre-approval %s is %s", ErrInvalidPreApproval, preApprovalID, status.Status)
+	}
+	if status.Expired() {
+		return fmt.Errorf("%w: pre-approval %s expired at %s", ErrInvalidPreApproval, preApprovalID, status.ExpirationDateTime)
+	}
+	if status.Payer.PartyID != "" && status.Payer.PartyID != payer.PartyID {
+		return fmt.Errorf("%w: pre-approval %s was granted by another payer", ErrInvalidPreApproval, preApprovalID)
+	}
+	if status.PayerCurrency != "" && status.PayerCurrency != currency {
+		return fmt.Errorf("%w: pre-approval %s is for %s, not %s", ErrInvalidPreApproval, preApprovalID, status.PayerCurrency, currency)
+	}
+
+	return nil
+}
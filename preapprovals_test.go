@@ -0,0 +1,47 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetApprovedPreApprovalsPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		party PartyInfo
+		want  string
+	}{
+		{"msisdn", PartyInfo{PartyIDType: MSISDN, PartyID: "+46 733 123 450"}, "/collection/v1_0/preapprovals/msisdn/46733123450"},
+		{"email", PartyInfo{PartyIDType: Email, PartyID: "jane/doe@example.com"}, "/collection/v1_0/preapprovals/email/jane%2Fdoe@example.com"},
+		{"party code", PartyInfo{PartyIDType: Party, PartyID: "shop-1"}, "/collection/v1_0/preapprovals/party_code/shop-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/collection/token/" {
+					fmt.Fprint(w, `{"access_token":"token","token_type":"access_token","expires_in":3600}`)
+					return
+				}
+				got = r.URL.EscapedPath()
+				fmt.Fprint(w, `[]`)
+			}))
+			defer srv.Close()
+
+			config := newTestConfig(t, srv.URL)
+			client := NewClient(config)
+			collection := NewCollectionService(client, config, NewAuthService(client, config))
+
+			if _, err := collection.GetApprovedPreApprovals(context.Background(), tt.party); err != nil {
+				t.Fatalf("GetApprovedPreApprovals: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("path = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// RemittanceService handles MTN MoMo remittance operations
+type RemittanceService struct {
+	client      *Client
+	config      *Config
+	authService TokenProvider
+}
+
+// NewRemittanceService creates a new remittance service
+func NewRemittanceService(client *Client, config *Config, authService TokenProvider) *RemittanceService {
+	return &RemittanceService{
+		client:      client,
+		config:      config,
+		authService: authService,
+	}
+}
+
+// RemittanceTransferOptions contains optional parameters for remittance transfers
+type RemittanceTransferOptions struct {
+	IdempotencyKey string // Custom idempotency key (generated if empty)
+	ExternalID     string // Custom external ID (generated if empty)
+	ReferenceID    string // Custom reference ID (generated if empty)
+	PayerMessage   string // Message from the payer
+	PayeeNote      string // Note to the payee
+	CallbackURL    string // Callback URL (built from the config's callback path if empty)
+}
+
+// CashTransferOptions contains the sender details and optional parameters
+// for cash transfers
+type CashTransferOptions struct {
+	IdempotencyKey     string // Custom idempotency key (generated if empty)
+	ExternalID         string // Custom external ID (generated if empty)
+	ReferenceID        string // Custom reference ID (generated if empty)
+	PayerMessage       string // Message from the payer
+	PayeeNote          string // Note to the payee
+	CallbackURL        string // Callback URL (built from the config's callback path if empty)
+	OriginatingCountry string // ISO code of the country the money is sent from
+	OriginalAmount     *Money // Amount in the sender's currency, if different
+	Payer              CashTransferPayer
+}
+
+// Transfer initiates a remittance transfer of an exact amount to a mobile
+// money account
+func (s *RemittanceService) Transfer(ctx context.Context, phone string, amount Money, opts *RemittanceTransferOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return "", err
+	}
+
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "remittance")
+	if err != nil {
+		return "", fmt.Errorf("error getting access token: %w", err)
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &RemittanceTransferOptions{}
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	// Create request payload
+	payload := TransferPayload{
+		Amount:     amount.AmountString(),
+		Currency:   amount.Currency,
+		ExternalID: externalID,
+		Payee: PartyInfo{
+			PartyIDType: MSISDN,
+			PartyID:     msisdn,
+		},
+		PayerMessage: defaultIfEmpty(opts.PayerMessage, "Remittance payment"),
+		PayeeNote:    defaultIfEmpty(opts.PayeeNote, "Funds received"),
+	}
+
+	// Create headers
+	headers, err := s.paymentHeaders(token, referenceID, opts.IdempotencyKey, CallbackRemittanceTransfer, opts.CallbackURL)
+	if err != nil {
+		return "", err
+	}
+
+	// Make the request
+	req := Request{
+		Method:  http.MethodPost,
+		Path:    "/remittance/v1_0/transfer",
+		Body:    payload,
+		Headers: headers,
+	}
+
+	err = s.client.DoRequest(ctx, req, nil)
+	if err != nil {
+		return "", fmt.Errorf("error making remittance transfer: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetTransferStatus checks the status of a remittance transfer
+func (s *RemittanceService) GetTransferStatus(ctx context.Context, referenceID string) (*TransactionStatusResponse, error) {
+	var result TransactionStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/remittance/v1_0/transfer/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking remittance transfer status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// WaitForFinalStatus polls the status of a remittance transfer until it is
+// no longer pending or the context expires. If the transfer failed, was
+// rejected or timed out, the final status is returned along with a
+// *TransactionError.
+func (s *RemittanceService) WaitForFinalStatus(ctx context.Context, referenceID string, opts *WaitOptions) (*TransactionStatusResponse, error) {
+	return waitForFinalStatus(ctx, referenceID, opts, s.GetTransferStatus)
+}
+
+// CashTransfer initiates a cross-border cash transfer of an exact amount to
+// a mobile money account
+func (s *RemittanceService) CashTransfer(ctx context.Context, phone string, amount Money, opts *CashTransferOptions) (string, error) {
+	if err := amount.validate(); err != nil {
+		return "", err
+	}
+
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return "", err
+	}
+
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "remittance")
+	if err != nil {
+		return "", fmt.Errorf("error getting access token: %w", err)
+	}
+
+	// Use provided options or create defaults
+	if opts == nil {
+		opts = &CashTransferOptions{}
+	}
+
+	// Generate or use provided reference ID
+	referenceID := opts.ReferenceID
+	if referenceID == "" {
+		referenceID = uuid.New().String()
+	}
+
+	// Generate or use provided external ID
+	externalID := opts.ExternalID
+	if externalID == "" {
+		externalID = uuid.New().String()
+	}
+
+	// Default the original amount to the transferred amount
+	originalAmount := amount
+	if opts.OriginalAmount != nil {
+		originalAmount = *opts.OriginalAmount
+	}
+
+	// Create request payload
+	payload := CashTransferPayload{
+		Amount:     amount.AmountString(),
+		Currency:   amount.Currency,
+		ExternalID: externalID,
+		Payee: PartyInfo{
+			PartyIDType: MSISDN,
+			PartyID:     msisdn,
+		},
+		OriginatingCountry: opts.OriginatingCountry,
+		OriginalAmount:     originalAmount.AmountString(),
+		OriginalCurrency:   originalAmount.Currency,
+		PayerMessage:       defaultIfEmpty(opts.PayerMessage, "Remittance payment"),
+		PayeeNote:          defaultIfEmpty(opts.PayeeNote, "Funds received"),
+		CashTransferPayer:  opts.Payer,
+	}
+
+	// Create headers
+	headers, err := s.paymentHeaders(token, referenceID, opts.IdempotencyKey, CallbackCashTransfer, opts.CallbackURL)
+	if err != nil {
+		return "", err
+	}
+
+	// Make the request
+	req := Request{
+		Method:  http.MethodPost,
+		Path:    "/remittance/v2_0/cashtransfer",
+		Body:    payload,
+		Headers: headers,
+	}
+
+	err = s.client.DoRequest(ctx, req, nil)
+	if err != nil {
+		return "", fmt.Errorf("error making cash transfer: %w", err)
+	}
+
+	return referenceID, nil
+}
+
+// GetCashTransferStatus checks the status of a cash transfer
+func (s *RemittanceService) GetCashTransferStatus(ctx context.Context, referenceID string) (*CashTransferStatusResponse, error) {
+	var result CashTransferStatusResponse
+	err := s.get(ctx, fmt.Sprintf("/remittance/v2_0/cashtransfer/%s", referenceID), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error checking cash transfer status: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetAccountBalance gets the balance of the account
+func (s *RemittanceService) GetAccountBalance(ctx context.Context) (string, string, error) {
+	balance, err := s.GetBalance(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	return balance.AvailableBalance, balance.Currency, nil
+}
+
+// GetBalance gets the balance of the account
+func (s *RemittanceService) GetBalance(ctx context.Context) (*Balance, error) {
+	var result Balance
+	err := s.get(ctx, "/remittance/v1_0/account/balance", &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account balance: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ValidateAccountHolder checks that a mobile money account exists and is active
+func (s *RemittanceService) ValidateAccountHolder(ctx context.Context, phone string) (bool, error) {
+	return s.IsAccountHolderActive(ctx, PartyInfo{PartyIDType: MSISDN, PartyID: phone})
+}
+
+// GetAccountBalanceInCurrency gets the balance of the account in a specific
+// currency, for wallets holding several currencies
+func (s *RemittanceService) GetAccountBalanceInCurrency(ctx context.Context, currency string) (*Balance, error) {
+	var result Balance
+	err := s.get(ctx, fmt.Sprintf("/remittance/v1_0/account/balance/%s", strings.ToUpper(currency)), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account balance in %s: %w", currency, err)
+	}
+
+	return &result, nil
+}
+
+// GetBalances gets the balance of the account in every configured currency.
+// Balances that could be fetched are returned even if others failed.
+func (s *RemittanceService) GetBalances(ctx context.Context) (map[string]*Balance, error) {
+	return getBalances(ctx, s.config.balanceCurrencies(), s.GetAccountBalanceInCurrency)
+}
+
+// IsAccountHolderActive checks that an account holder exists and is active.
+// The account holder can be identified by MSISDN, email or party code.
+func (s *RemittanceService) IsAccountHolderActive(ctx context.Context, accountHolder PartyInfo) (bool, error) {
+	party, err := s.config.normalizeParty(accountHolder)
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Result bool `json:"result"`
+	}
+	err = s.get(ctx, accountHolderPath("remittance", party, "active"), &result)
+	if err != nil {
+		return false, fmt.Errorf("error validating account holder: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// GetAccountHolderInfo gets information about an account holder
+func (s *RemittanceService) GetAccountHolderInfo(ctx context.Context, phone string) (*AccountHolderInfo, error) {
+	// Normalize phone number for the configured market
+	msisdn, err := s.config.normalizeMSISDN(phone)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AccountHolderInfo
+	err = s.get(ctx, fmt.Sprintf("/remittance/v1_0/accountholder/MSISDN/%s/basicuserinfo", msisdn), &result)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account holder info: %w", err)
+	}
+
+	return &result, nil
+}
+
+// paymentHeaders builds the headers for a remittance payment request
+func (s *RemittanceService) paymentHeaders(token, referenceID, idempotencyKey string, kind CallbackKind, callbackOverride string) (map[string]string, error) {
+	headers := map[string]string{
+		"Authorization":             "Bearer " + token,
+		"X-Reference-Id":            referenceID,
+		"X-Target-Environment":      s.config.TargetEnvironment,
+		"Ocp-Apim-Subscription-Key": s.config.RemittanceKey,
+	}
+
+	// Add idempotency key if provided
+	if idempotencyKey != "" {
+		headers["X-Idempotency-Key"] = idempotencyKey
+	}
+
+	// Add callback URL if provided or configured
+	callbackURL, err := s.config.callbackURL(kind, referenceID, callbackOverride)
+	if err != nil {
+		return nil, err
+	}
+	if callbackURL != "" {
+		headers["X-Callback-Url"] = callbackURL
+	}
+
+	return headers, nil
+}
+
+// get performs an authenticated GET request against the remittance API
+func (s *RemittanceService) get(ctx context.Context, path string, result interface{}) error {
+	// Get access token
+	token, err := s.authService.GetAccessToken(ctx, "remittance")
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	req := Request{
+		Method: http.MethodGet,
+		Path:   path,
+		Headers: map[string]string{
+			"Authorization":             "Bearer " + token,
+			"X-Target-Environment":      s.config.TargetEnvironment,
+			"Ocp-Apim-Subscription-Key": s.config.RemittanceKey,
+		},
+	}
+
+	return s.client.DoRequest(ctx, req, result)
+}
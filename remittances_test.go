@@ -0,0 +1,265 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// remittanceRequest is a request received by remittanceServer
+type remittanceRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// remittanceServer serves the remittance token endpoint and answers every
+// other request with the given status and body, recording what it received.
+// Requests made with another product's key or token are rejected.
+func remittanceServer(t *testing.T, status int, body string) (*RemittanceService, *[]remittanceRequest) {
+	t.Helper()
+
+	var requests []remittanceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "remittance-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/remittance/token/" {
+			fmt.Fprint(w, `{"access_token":"remittance-token","token_type":"access_token","expires_in":3600}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer remittance-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		requests = append(requests, remittanceRequest{r.Method, r.URL.Path, r.Header, data})
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := newTestConfig(t, srv.URL, WithRemittanceKey("remittance-key"))
+	client := NewClient(config)
+	return NewRemittanceService(client, config, NewAuthService(client, config)), &requests
+}
+
+// onlyRequest returns the single API request a test expects
+func onlyRequest(t *testing.T, requests []remittanceRequest) remittanceRequest {
+	t.Helper()
+
+	if len(requests) != 1 {
+		t.Fatalf("got %d API requests, want 1", len(requests))
+	}
+	return requests[0]
+}
+
+func TestRemittanceTransfer(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusAccepted, "")
+
+	referenceID, err := remittance.Transfer(context.Background(), "46733123454", NewMoney(1050, "EUR"), &RemittanceTransferOptions{
+		ExternalID:   "ext-1",
+		PayerMessage: "school fees",
+	})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	req := onlyRequest(t, *requests)
+	if req.method != http.MethodPost || req.path != "/remittance/v1_0/transfer" {
+		t.Errorf("request = %s %s, want POST /remittance/v1_0/transfer", req.method, req.path)
+	}
+	if got := req.header.Get("X-Reference-Id"); got != referenceID {
+		t.Errorf("X-Reference-Id = %q, want %q", got, referenceID)
+	}
+
+	var payload TransferPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("decoding payload %s: %v", req.body, err)
+	}
+	want := TransferPayload{
+		Amount:       "10.50",
+		Currency:     "EUR",
+		ExternalID:   "ext-1",
+		Payee:        PartyInfo{PartyIDType: MSISDN, PartyID: "46733123454"},
+		PayerMessage: "school fees",
+		PayeeNote:    "Funds received",
+	}
+	if payload != want {
+		t.Errorf("payload = %+v, want %+v", payload, want)
+	}
+}
+
+func TestRemittanceCashTransfer(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusAccepted, "")
+
+	original := NewMoney(2000, "USD")
+	_, err := remittance.CashTransfer(context.Background(), "46733123454", NewMoney(1850, "EUR"), &CashTransferOptions{
+		ExternalID:         "ext-2",
+		OriginatingCountry: "US",
+		OriginalAmount:     &original,
+		Payer: CashTransferPayer{
+			IdentificationType:   "PASS",
+			IdentificationNumber: "P1234567",
+			FirstName:            "Ada",
+			SurName:              "Lovelace",
+			MSISDN:               "15551234567",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CashTransfer: %v", err)
+	}
+
+	req := onlyRequest(t, *requests)
+	if req.method != http.MethodPost || req.path != "/remittance/v2_0/cashtransfer" {
+		t.Errorf("request = %s %s, want POST /remittance/v2_0/cashtransfer", req.method, req.path)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("decoding payload %s: %v", req.body, err)
+	}
+	want := map[string]string{
+		"amount":                    "18.50",
+		"currency":                  "EUR",
+		"externalId":                "ext-2",
+		"originatingCountry":        "US",
+		"originalAmount":            "20.00",
+		"originalCurrency":          "USD",
+		"payerIdentificationType":   "PASS",
+		"payerIdentificationNumber": "P1234567",
+		"payerFirstName":            "Ada",
+		"payerSurName":              "Lovelace",
+		"payerMsisdn":               "15551234567",
+	}
+	for key, value := range want {
+		if payload[key] != value {
+			t.Errorf("%s = %v, want %s", key, payload[key], value)
+		}
+	}
+	for _, key := range []string{"payerEmail", "payerGender", "payerIdentity"} {
+		if _, ok := payload[key]; ok {
+			t.Errorf("empty payer field %s was sent", key)
+		}
+	}
+	if payee, _ := payload["payee"].(map[string]interface{}); payee["partyId"] != "46733123454" {
+		t.Errorf("payee = %v, want MSISDN 46733123454", payload["payee"])
+	}
+}
+
+func TestRemittanceCashTransferOriginalAmountDefault(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusAccepted, "")
+
+	if _, err := remittance.CashTransfer(context.Background(), "46733123454", NewMoney(1850, "EUR"), nil); err != nil {
+		t.Fatalf("CashTransfer: %v", err)
+	}
+
+	var payload CashTransferPayload
+	if err := json.Unmarshal(onlyRequest(t, *requests).body, &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload.OriginalAmount != "18.50" || payload.OriginalCurrency != "EUR" {
+		t.Errorf("original amount = %s %s, want the transferred 18.50 EUR", payload.OriginalAmount, payload.OriginalCurrency)
+	}
+}
+
+func TestRemittanceGetTransferStatus(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusOK,
+		`{"amount":"10.50","currency":"EUR","externalId":"ext-1","status":"FAILED","reason":"PAYEE_NOT_FOUND"}`)
+
+	status, err := remittance.GetTransferStatus(context.Background(), "ref-1")
+	if err != nil {
+		t.Fatalf("GetTransferStatus: %v", err)
+	}
+
+	req := onlyRequest(t, *requests)
+	if req.method != http.MethodGet || req.path != "/remittance/v1_0/transfer/ref-1" {
+		t.Errorf("request = %s %s, want GET /remittance/v1_0/transfer/ref-1", req.method, req.path)
+	}
+	if status.Status != Failed || status.Reason != "PAYEE_NOT_FOUND" || status.Amount != "10.50" {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestRemittanceGetCashTransferStatus(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusOK, `{
+		"amount":"18.50","currency":"EUR","externalId":"ext-2","status":"SUCCESSFUL",
+		"financialTransactionId":"ft-2","originatingCountry":"US","originalAmount":"20.00",
+		"originalCurrency":"USD","payerFirstName":"Ada","payerMsisdn":"15551234567"
+	}`)
+
+	status, err := remittance.GetCashTransferStatus(context.Background(), "ref-2")
+	if err != nil {
+		t.Fatalf("GetCashTransferStatus: %v", err)
+	}
+
+	req := onlyRequest(t, *requests)
+	if req.method != http.MethodGet || req.path != "/remittance/v2_0/cashtransfer/ref-2" {
+		t.Errorf("request = %s %s, want GET /remittance/v2_0/cashtransfer/ref-2", req.method, req.path)
+	}
+	if status.Status != Successful || status.FinancialTransactionID != "ft-2" {
+		t.Errorf("status = %+v", status.TransactionStatusResponse)
+	}
+	if status.OriginatingCountry != "US" || status.OriginalAmount != "20.00" || status.OriginalCurrency != "USD" {
+		t.Errorf("original = %s %s from %s", status.OriginalAmount, status.OriginalCurrency, status.OriginatingCountry)
+	}
+	if status.FirstName != "Ada" || status.MSISDN != "15551234567" {
+		t.Errorf("payer = %+v", status.CashTransferPayer)
+	}
+}
+
+func TestRemittanceGetBalance(t *testing.T) {
+	remittance, requests := remittanceServer(t, http.StatusOK, `{"availableBalance":"1250.75","currency":"EUR"}`)
+
+	balance, err := remittance.GetBalance(context.Background())
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+
+	req := onlyRequest(t, *requests)
+	if req.method != http.MethodGet || req.path != "/remittance/v1_0/account/balance" {
+		t.Errorf("request = %s %s, want GET /remittance/v1_0/account/balance", req.method, req.path)
+	}
+	if balance.AvailableBalance != "1250.75" || balance.Currency != "EUR" {
+		t.Errorf("balance = %s %s, want 1250.75 EUR", balance.AvailableBalance, balance.Currency)
+	}
+	if balance.Available != NewMoney(125075, "EUR") {
+		t.Errorf("Available = %v, want 1250.75 EUR", balance.Available)
+	}
+}
+
+func TestRemittanceValidateAccountHolder(t *testing.T) {
+	tests := []struct {
+		body string
+		want bool
+	}{
+		{`{"result":true}`, true},
+		{`{"result":false}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			remittance, requests := remittanceServer(t, http.StatusOK, tt.body)
+
+			active, err := remittance.ValidateAccountHolder(context.Background(), "46733123454")
+			if err != nil {
+				t.Fatalf("ValidateAccountHolder: %v", err)
+			}
+			if active != tt.want {
+				t.Errorf("active = %v, want %v", active, tt.want)
+			}
+
+			req := onlyRequest(t, *requests)
+			if want := "/remittance/v1_0/accountholder/msisdn/46733123454/active"; req.method != http.MethodGet || req.path != want {
+				t.Errorf("request = %s %s, want GET %s", req.method, req.path, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed API requests are retried
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first one (1 disables retries)
+	InitialBackoff time.Duration // Delay before the first retry
+	MaxBackoff     time.Duration // Upper bound for the delay between attempts
+	Multiplier     float64       // Factor applied to the delay after each retry
+	Jitter         float64       // Random fraction (0-1) subtracted from each delay
+
+	// Retry budget shared by all requests of a client. Every retry spends one
+	// token and every successful request earns BudgetRefill tokens back, so a
+	// failing API is not flooded with retries. Zero BudgetTokens disables it.
+	BudgetTokens float64
+	BudgetRefill float64
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		BudgetTokens:   10,
+		BudgetRefill:   0.1,
+	}
+}
+
+// NoRetryPolicy returns a policy that makes a single attempt per request
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// backoff returns the delay before the given retry (1 for the first retry)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// retryDelay returns the delay before the given retry. A Retry-After header
+// on the failed response replaces the computed backoff, but is still capped
+// at MaxBackoff so a server cannot stall the caller indefinitely.
+func (p RetryPolicy) retryDelay(retry int, err error) time.Duration {
+	wait, ok := retryAfter(err)
+	if !ok {
+		return p.backoff(retry)
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// retryBudget limits the number of retries a client makes across requests
+type retryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	refill float64
+}
+
+// newRetryBudget creates a budget for the policy, or nil if it has none
+func newRetryBudget(p RetryPolicy) *retryBudget {
+	if p.BudgetTokens <= 0 {
+		return nil
+	}
+	return &retryBudget{
+		tokens: p.BudgetTokens,
+		max:    p.BudgetTokens,
+		refill: p.BudgetRefill,
+	}
+}
+
+// withdraw spends a token for a retry, reporting whether one was available
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// deposit returns part of a token after a successful request
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.max, b.tokens+b.refill)
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableError reports whether a failed attempt may be retried
+func isRetryableError(err error) bool {
+	var momoErr *MoMoError
+	if errors.As(err, &momoErr) {
+		return isRetryableStatus(momoErr.StatusCode)
+	}
+	if errors.Is(err, ErrInvalidResponse) || errors.Is(err, ErrCassetteMismatch) {
+		return false
+	}
+	// Anything else is a network error
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryAfter parses the Retry-After header of a failed response, if any
+func retryAfter(err error) (time.Duration, bool) {
+	var momoErr *MoMoError
+	if !errors.As(err, &momoErr) || momoErr.Header == nil {
+		return 0, false
+	}
+
+	value := momoErr.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at), true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether a request can safely be sent more than once.
+// POSTs are only safe when they carry an X-Reference-Id, since resending the
+// same reference ID cannot create a second transaction.
+func (r Request) isIdempotent() bool {
+	if r.Idempotent {
+		return true
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return r.referenceID() != ""
+	default:
+		return false
+	}
+}
+
+// referenceID returns the X-Reference-Id header of the request, if set
+func (r Request) referenceID() string {
+	for key, value := range r.Headers {
+		if http.CanonicalHeaderKey(key) == "X-Reference-Id" {
+			return value
+		}
+	}
+	return ""
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,200 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.backoff(tt.retry); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("backoff(1) = %v, want within [500ms, 1s]", got)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 5 * time.Second, Multiplier: 2}
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{"no header", "", 100 * time.Millisecond},
+		{"seconds", "2", 2 * time.Second},
+		{"capped at MaxBackoff", "3600", 5 * time.Second},
+		{"date in the past", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+		{"garbage", "soon", 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.retryAfter != "" {
+				header.Set("Retry-After", tt.retryAfter)
+			}
+			err := &MoMoError{StatusCode: http.StatusServiceUnavailable, Header: header}
+			if got := policy.retryDelay(1, err); got != tt.want {
+				t.Errorf("retryDelay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := newRetryBudget(RetryPolicy{BudgetTokens: 2, BudgetRefill: 0.5})
+
+	if !budget.withdraw() || !budget.withdraw() {
+		t.Fatal("withdraw failed with tokens left")
+	}
+	if budget.withdraw() {
+		t.Fatal("withdraw succeeded with an empty budget")
+	}
+
+	budget.deposit()
+	if budget.withdraw() {
+		t.Fatal("withdraw succeeded with half a token")
+	}
+	budget.deposit()
+	if !budget.withdraw() {
+		t.Fatal("withdraw failed after refilling a whole token")
+	}
+
+	// Deposits never exceed the budget
+	for i := 0; i < 10; i++ {
+		budget.deposit()
+	}
+	if budget.tokens != 2 {
+		t.Errorf("tokens = %v, want 2", budget.tokens)
+	}
+
+	if newRetryBudget(RetryPolicy{}) != nil {
+		t.Error("zero BudgetTokens should disable the budget")
+	}
+}
+
+// flakyServer fails the first failures requests with status and then succeeds
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"code":"SERVICE_UNAVAILABLE","message":"try again"}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	fast := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		req      Request
+		failures int32
+		status   int
+		wantErr  bool
+		wantHits int32
+	}{
+		{"GET recovers", fast, Request{Method: http.MethodGet, Path: "/x"}, 2, http.StatusServiceUnavailable, false, 3},
+		{"GET gives up", fast, Request{Method: http.MethodGet, Path: "/x"}, 3, http.StatusServiceUnavailable, true, 3},
+		{"client errors are final", fast, Request{Method: http.MethodGet, Path: "/x"}, 1, http.StatusBadRequest, true, 1},
+		{"POST without reference ID", fast, Request{Method: http.MethodPost, Path: "/x"}, 1, http.StatusServiceUnavailable, true, 1},
+		{"POST with reference ID", fast, Request{Method: http.MethodPost, Path: "/x", Headers: map[string]string{"X-Reference-Id": "ref"}}, 1, http.StatusServiceUnavailable, false, 2},
+		{"budget exhausted", RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BudgetTokens: 1}, Request{Method: http.MethodGet, Path: "/x"}, 3, http.StatusServiceUnavailable, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := flakyServer(t, tt.failures, tt.status, nil)
+			client := NewClient(newTestConfig(t, srv.URL, WithRetryPolicy(tt.policy)))
+
+			err := client.DoRequest(context.Background(), tt.req, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n := hits.Load(); n != tt.wantHits {
+				t.Errorf("server hit %d times, want %d", n, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetryAfterRespectsDeadline(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"3600"}}
+	srv, hits := flakyServer(t, 1, http.StatusServiceUnavailable, header)
+
+	policy := DefaultRetryPolicy()
+	client := NewClient(newTestConfig(t, srv.URL, WithRetryPolicy(policy)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := client.DoRequest(ctx, Request{Method: http.MethodGet, Path: "/x"}, nil)
+	if !errors.Is(err, ErrAPIRequestFailed) {
+		t.Errorf("err = %v, want the 503", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("DoRequest waited %v, want it to give up before the deadline", elapsed)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+}
+
+func TestDoRequestRetryAfterCapped(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"3600"}}
+	srv, hits := flakyServer(t, 1, http.StatusServiceUnavailable, header)
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+	client := NewClient(newTestConfig(t, srv.URL, WithRetryPolicy(policy)))
+
+	start := time.Now()
+	if err := client.DoRequest(context.Background(), Request{Method: http.MethodGet, Path: "/x"}, nil); err != nil {
+		t.Fatalf("DoRequest: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DoRequest waited %v, want Retry-After capped at MaxBackoff", elapsed)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("server hit %d times, want 2", n)
+	}
+}